@@ -0,0 +1,70 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"os"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/bank-vaults/vault-operator/pkg/apis"
+	"github.com/bank-vaults/vault-operator/pkg/controller/vault"
+)
+
+const decryptKeysCommand = "decrypt-keys"
+
+// decryptKeys runs the key decryptor sidecar, which keeps the encrypted unseal keys of a Vault
+// decrypted for the bank-vaults containers next to it
+func decryptKeys(args []string) {
+	flags := flag.NewFlagSet(decryptKeysCommand, flag.ExitOnError)
+	vaultNamespace := flags.String("vault-namespace", "", "Namespace of the Vault")
+	vaultName := flags.String("vault-name", "", "Name of the Vault")
+	keysDir := flags.String("keys-dir", "", "Directory to write the decrypted keys to")
+	rootTokenOnly := flags.Bool("root-token-only", false, "Decrypts only the root token")
+	verbose := flags.Bool("verbose", false, "Enables verbose logging")
+	_ = flags.Parse(args)
+
+	ctrl.SetLogger(zap.New(zap.UseDevMode(*verbose)))
+
+	if *vaultNamespace == "" || *vaultName == "" || *keysDir == "" {
+		log.Info("--vault-namespace, --vault-name and --keys-dir are required")
+		os.Exit(1)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		log.Error(err, "unable to add scheme")
+		os.Exit(1)
+	}
+	if err := apis.AddToScheme(scheme); err != nil {
+		log.Error(err, "unable to add scheme")
+		os.Exit(1)
+	}
+
+	c, err := client.New(config.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
+		log.Error(err, "unable to create client")
+		os.Exit(1)
+	}
+
+	vaultKey := client.ObjectKey{Namespace: *vaultNamespace, Name: *vaultName}
+	vault.RunKeyDecryptor(ctrl.SetupSignalHandler(), c, vaultKey, *keysDir, *rootTokenOnly)
+}
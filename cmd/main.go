@@ -18,6 +18,7 @@ import (
 	"flag"
 	"net"
 	"os"
+	"strings"
 	"time"
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -32,26 +33,41 @@ import (
 	"github.com/bank-vaults/vault-operator/pkg/apis"
 	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
 	"github.com/bank-vaults/vault-operator/pkg/controller"
+	"github.com/bank-vaults/vault-operator/pkg/controller/vault"
 )
 
 const (
 	envOperatorNamespace   = "OPERATOR_NAMESPACE"
+	envPodNamespace        = "POD_NAMESPACE"
 	envWatchNamespace      = "WATCH_NAMESPACE"
 	envKubeServiceHost     = "KUBERNETES_SERVICE_HOST"
 	envKubeServicePort     = "KUBERNETES_SERVICE_PORT"
 	envBankVaultsImage     = "BANK_VAULTS_IMAGE"
+	envOperatorImage       = "OPERATOR_IMAGE"
 	healthProbeBindAddress = ":8080"
 	metricsBindAddress     = ":8383"
 	defaultSyncPeriod      = 30 * time.Second
+
+	serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
 )
 
 var log = ctrl.Log.WithName("cmd")
 
 func main() {
+	// The key decryptor sidecars of the Vault Pods run the operator image
+	if len(os.Args) > 1 && os.Args[1] == decryptKeysCommand {
+		decryptKeys(os.Args[2:])
+		return
+	}
+
 	// Register CLI flags
 	syncPeriod := flag.Duration("sync_period", defaultSyncPeriod,
 		"Determines the minimum frequency at which watched resources are reconciled")
 	verbose := flag.Bool("verbose", false, "Enables verbose logging")
+	clusterDomain := flag.String("cluster_domain", "",
+		"DNS domain of the cluster, detected from the Kubernetes API Service if empty")
+	disableRemediation := flag.Bool("disable_remediation", false,
+		"Turns off the remediation of unhealthy Vault pods for every Vault, they are only reported")
 	flag.Parse()
 
 	// The logger instantiated here can be changed to any logger
@@ -65,6 +81,36 @@ func main() {
 	if defaultImage != "" {
 		vaultv1alpha1.DefaultBankVaultsImage = defaultImage
 	}
+	if operatorImage := os.Getenv(envOperatorImage); operatorImage != "" {
+		vault.OperatorImage = operatorImage
+	}
+
+	// Configure the cluster domain
+	if *clusterDomain == "" {
+		detected, err := vault.DetectClusterDomain()
+		if err != nil {
+			log.Info("unable to detect the cluster domain, using the default", "domain", vault.ClusterDomain, "error", err.Error())
+		} else {
+			*clusterDomain = detected
+		}
+	}
+	if *clusterDomain != "" {
+		vault.ClusterDomain = *clusterDomain
+	}
+	log.Info("cluster domain: " + vault.ClusterDomain)
+
+	vault.RemediationDisabled = *disableRemediation
+	if vault.RemediationDisabled {
+		log.Info("remediation of unhealthy Vault pods is disabled")
+	}
+
+	// The namespace of the operator pods is allowed to reach Vault by the NetworkPolicies
+	vault.OperatorNamespace = os.Getenv(envPodNamespace)
+	if vault.OperatorNamespace == "" {
+		if namespace, err := os.ReadFile(serviceAccountNamespaceFile); err == nil {
+			vault.OperatorNamespace = strings.TrimSpace(string(namespace))
+		}
+	}
 
 	// Get namespace config
 	namespace := os.Getenv(envOperatorNamespace)
@@ -72,12 +118,16 @@ func main() {
 		namespace = os.Getenv(envWatchNamespace)
 	}
 
+	// A comma separated list watches the namespaces of the configuration resources besides the one of the Vaults
 	namespaces := make(map[string]cache.Config)
-	if namespace == "" {
+	for _, ns := range strings.Split(namespace, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			namespaces[ns] = cache.Config{}
+			log.Info("watched namespace: " + ns)
+		}
+	}
+	if len(namespaces) == 0 {
 		log.Info("no watched namespace found, watching the entire cluster")
-	} else {
-		namespaces[namespace] = cache.Config{}
-		log.Info("watched namespace: " + namespace)
 	}
 
 	// Load kube client config
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"reflect"
 	"sort"
 	"strings"
@@ -31,6 +32,9 @@ import (
 	netv1 "k8s.io/api/networking/v1"
 	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
@@ -118,6 +122,18 @@ type VaultSpec struct {
 	// default:
 	WatchedSecretsAnnotations []map[string]string `json:"watchedSecretsAnnotations,omitempty"`
 
+	// WatchedSecretsSelectors specifies a set of Kubernetes label selectors which select Secrets to watch.
+	// Unlike WatchedSecretsLabels these support set-based requirements (matchExpressions) as well.
+	// If these Secrets change the Vault cluster gets restarted.
+	// default:
+	WatchedSecretsSelectors []metav1.LabelSelector `json:"watchedSecretsSelectors,omitempty"`
+
+	// WatchedSecretsAnnotationsSelectors specifies a set of selectors which are matched against the annotations
+	// of Secrets to watch. Unlike WatchedSecretsAnnotations these support set-based requirements (matchExpressions) as well.
+	// If these Secrets change the Vault cluster gets restarted.
+	// default:
+	WatchedSecretsAnnotationsSelectors []metav1.LabelSelector `json:"watchedSecretsAnnotationsSelectors,omitempty"`
+
 	// Annotations define a set of common Kubernetes annotations that will be added to all operator managed resources.
 	// default:
 	Annotations map[string]string `json:"annotations,omitempty"`
@@ -173,6 +189,57 @@ type VaultSpec struct {
 	// default:
 	ExternalConfig extv1beta1.JSON `json:"externalConfig,omitempty"`
 
+	// ConfigurerMode selects how the ExternalConfig gets applied to Vault:
+	// - "deployment": the Bank Vaults Configurer runs as a long-running Deployment
+	// - "operator": the operator applies policies, auth methods, secret engines, audit devices
+	//   and startup secrets itself through the Vault API, and reports the result in status
+	// default: deployment
+	// +kubebuilder:validation:Enum=deployment;operator
+	ConfigurerMode string `json:"configurerMode,omitempty"`
+
+	// ConfigResourceNamespaces define a list of namespaces where VaultPolicy, VaultAuthBackend, VaultSecretEngine
+	// and VaultAuditDevice resources are allowed to reference this Vault, use ["*"] for all namespaces.
+	// The namespace of the Vault is always allowed. An operator watching only some namespaces has to watch these as well.
+	// default:
+	ConfigResourceNamespaces []string `json:"configResourceNamespaces,omitempty"`
+
+	// TransitUnsealNamespaces define a list of namespaces where Vault resources are allowed to auto-unseal
+	// with the transit secret engine of this Vault through UnsealConfig.VaultRef, use ["*"] for all namespaces.
+	// The namespace of the Vault is always allowed.
+	// default:
+	TransitUnsealNamespaces []string `json:"transitUnsealNamespaces,omitempty"`
+
+	// DriftDetection makes the operator periodically compare the policies, secret engines, auth methods
+	// and audit devices in Vault with the ExternalConfig, and report the differences in status.
+	// default:
+	DriftDetection *DriftDetection `json:"driftDetection,omitempty"`
+
+	// EmergencySeal makes the operator seal every Vault pod and keep them sealed until it is cleared,
+	// the vault.banzaicloud.io/emergency-seal: "true" annotation of the Vault has the same effect.
+	// The unsealer is removed from the pods meanwhile, the pods still running it are restarted sealed.
+	// Auto-unsealed pods unseal themselves when restarted, so they are only sealed through the Vault API.
+	// default: false
+	EmergencySeal bool `json:"emergencySeal,omitempty"`
+
+	// KubernetesAuth makes the operator enable and configure the Kubernetes auth method, with the host, the CA
+	// and the issuer of its own in-cluster config, and write the declared roles.
+	// Vault reviews the tokens with the token of its own ServiceAccount, which needs the system:auth-delegator
+	// ClusterRole, it is bound by the operator if RBAC.Create is set.
+	// default:
+	KubernetesAuth *KubernetesAuthConfig `json:"kubernetesAuth,omitempty"`
+
+	// OperatorIdentity makes the operator create its own Kubernetes auth method, policy and role in Vault
+	// with the root token once, and authenticate with its ServiceAccount instead of the root token afterwards.
+	// The root token is stored at init even if StoreRootToken is false, and it is revoked and removed from
+	// the unseal keys Secret once the identity is bootstrapped.
+	// default:
+	OperatorIdentity *OperatorIdentityConfig `json:"operatorIdentity,omitempty"`
+
+	// Remediation makes the operator act on Vault pods which stay sealed, uninitialised or unreachable,
+	// the -disable_remediation flag of the operator turns it off for every Vault.
+	// default: unhealthy pods are only reported
+	Remediation *RemediationPolicy `json:"remediation,omitempty"`
+
 	// UnsealConfig defines where the Vault cluster's unseal keys and root token should be stored after initialization.
 	// See the type's documentation for more details. Only one method may be specified.
 	// default: Kubernetes Secret based unsealing
@@ -195,6 +262,28 @@ type VaultSpec struct {
 	// default: ClusterIP
 	ServiceType string `json:"serviceType,omitempty"`
 
+	// IPFamilyPolicy is the Kubernetes IPFamilyPolicy of the Services created for Vault,
+	// set it to PreferDualStack or RequireDualStack on dual-stack clusters.
+	// default: the cluster default (SingleStack)
+	IPFamilyPolicy *v1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+
+	// IPFamilies are the Kubernetes IPFamilies of the Services created for Vault, in order of preference.
+	// default: the cluster default
+	IPFamilies []v1.IPFamily `json:"ipFamilies,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudget of the Vault pods.
+	// default: created for clusters with more than one instance
+	PodDisruptionBudget *PodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+
+	// NetworkPolicy restricts the traffic towards the Vault and the configurer pods.
+	// default:
+	NetworkPolicy *NetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// PerInstanceServicesDisabled disables the per-instance ClusterIP Services, the Vault pods are still
+	// reachable through their DNS names in the headless Service.
+	// default: false
+	PerInstanceServicesDisabled bool `json:"perInstanceServicesDisabled,omitempty"`
+
 	// LoadBalancerIP is an optional setting for allocating a specific address for the entry service object
 	// of type LoadBalancer
 	// default: ""
@@ -205,6 +294,18 @@ type VaultSpec struct {
 	// default: false
 	ServiceRegistrationEnabled bool `json:"serviceRegistrationEnabled,omitempty"`
 
+	// ServiceTarget selects the Vault instances behind the main Vault Service:
+	// - "active": only the active instance, requires ServiceRegistrationEnabled
+	// - "all": all the instances, the standbys forward the requests to the active instance
+	// The <name>-active and <name>-standby Services are created as well if ServiceRegistrationEnabled is set.
+	// default: active if ServiceRegistrationEnabled, all otherwise
+	ServiceTarget string `json:"serviceTarget,omitempty"`
+
+	// PerformanceStandbyServiceEnabled makes the <name>-standby Service select only the performance standby
+	// instances (Vault Enterprise), instead of all the standby instances.
+	// default: false
+	PerformanceStandbyServiceEnabled bool `json:"performanceStandbyServiceEnabled,omitempty"`
+
 	// RaftLeaderAddress defines the leader address of the raft cluster in multi-cluster deployments.
 	// (In single cluster (namespace) deployments it is automatically detected).
 	// "self" is a special value which means that this instance should be the bootstrap leader instance.
@@ -216,7 +317,13 @@ type VaultSpec struct {
 	// default: ""
 	RaftLeaderApiSchemeOverride string `json:"raftLeaderApiSchemeOverride,omitempty"`
 
+	// RaftRetryJoin adds a retry_join stanza with the pod DNS names to the Raft storage config, unless it has one.
+	// Enabling it on a running cluster changes the Vault config, which restarts the Vault pods.
+	// default: false
+	RaftRetryJoin bool `json:"raftRetryJoin,omitempty"`
+
 	// ServicePorts is an extra map of ports that should be exposed by the Vault Service.
+	// The api-port and cluster-port entries override the Vault API and cluster ports.
 	// default:
 	ServicePorts map[string]int32 `json:"servicePorts,omitempty"`
 
@@ -247,6 +354,11 @@ type VaultSpec struct {
 	// default: default
 	ServiceAccount string `json:"serviceAccount,omitempty"`
 
+	// RBAC makes the operator create the ServiceAccount of the Vault Pods, with a Role and RoleBinding granting
+	// only the permissions needed by the enabled features.
+	// default:
+	RBAC *RBAC `json:"rbac,omitempty"`
+
 	// Volumes define some extra Kubernetes Volumes for the Vault Pods.
 	// default:
 	Volumes []v1.Volume `json:"volumes,omitempty"`
@@ -283,6 +395,12 @@ type VaultSpec struct {
 	// default:
 	Ingress *Ingress `json:"ingress,omitempty"`
 
+	// Gateway, if it is specified the operator will create a Gateway API HTTPRoute or TLSRoute for the Vault Service,
+	// and a BackendTLSPolicy trusting the Vault CA when TLS is terminated at the Gateway.
+	// See the type for more details.
+	// default:
+	Gateway *Gateway `json:"gateway,omitempty"`
+
 	// ServiceMonitorEnabled enables the creation of Prometheus Operator specific ServiceMonitor for Vault.
 	// default: false
 	ServiceMonitorEnabled bool `json:"serviceMonitorEnabled,omitempty"`
@@ -293,6 +411,11 @@ type VaultSpec struct {
 	// default: ""
 	ExistingTLSSecretName string `json:"existingTlsSecretName,omitempty"`
 
+	// InsecureSkipTLSVerify makes the operator skip the verification of the Vault TLS certificates,
+	// otherwise they are verified with the CA of the generated or the ExistingTLSSecretName Secret.
+	// default: false
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+
 	// TLSExpiryThreshold is the Vault TLS certificate expiration threshold in Go's Duration format.
 	// default: 168h
 	TLSExpiryThreshold string `json:"tlsExpiryThreshold,omitempty"`
@@ -416,6 +539,20 @@ func (spec *VaultSpec) GetServiceAccount() string {
 	return "default"
 }
 
+// IsRBACManaged checks if the operator creates the ServiceAccount and the RBAC resources of Vault
+func (spec *VaultSpec) IsRBACManaged() bool {
+	return spec.RBAC != nil && spec.RBAC.Create
+}
+
+// GetServiceAccountName returns the Kubernetes Service Account of the Vault Pods,
+// the operator managed one is named after the Vault if ServiceAccount is not set
+func (vault *Vault) GetServiceAccountName() string {
+	if vault.Spec.IsRBACManaged() && vault.Spec.ServiceAccount == "" {
+		return vault.Name
+	}
+	return vault.Spec.GetServiceAccount()
+}
+
 // HasStorageHAEnabled detects if the ha_enabled field is set to true in Vault's storage stanza
 func (spec *VaultSpec) HasStorageHAEnabled() bool {
 	storageType := spec.GetStorageType()
@@ -532,6 +669,36 @@ func (spec *VaultSpec) GetWatchedSecretsAnnotations() []map[string]string {
 	return spec.WatchedSecretsAnnotations
 }
 
+// GetWatchedSecretsSelectors returns the label and annotation selectors of the Secrets to watch in the vault namespace,
+// merging the exact-match maps with the set-based selectors
+func (spec *VaultSpec) GetWatchedSecretsSelectors() ([]labels.Selector, []labels.Selector, error) {
+	var labelsSelectors, annotationsSelectors []labels.Selector
+
+	for _, l := range spec.GetWatchedSecretsLabels() {
+		labelsSelectors = append(labelsSelectors, labels.SelectorFromSet(l))
+	}
+	for i := range spec.WatchedSecretsSelectors {
+		selector, err := metav1.LabelSelectorAsSelector(&spec.WatchedSecretsSelectors[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid watched secrets selector: %w", err)
+		}
+		labelsSelectors = append(labelsSelectors, selector)
+	}
+
+	for _, a := range spec.GetWatchedSecretsAnnotations() {
+		annotationsSelectors = append(annotationsSelectors, labels.SelectorFromSet(a))
+	}
+	for i := range spec.WatchedSecretsAnnotationsSelectors {
+		selector, err := metav1.LabelSelectorAsSelector(&spec.WatchedSecretsAnnotationsSelectors[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid watched secrets annotations selector: %w", err)
+		}
+		annotationsSelectors = append(annotationsSelectors, selector)
+	}
+
+	return labelsSelectors, annotationsSelectors, nil
+}
+
 // GetAnnotations returns the Common Annotations
 func (spec *VaultSpec) GetAnnotations() map[string]string {
 	if spec.Annotations == nil {
@@ -553,6 +720,67 @@ func (spec *VaultSpec) GetAPIPortName() string {
 	return portName
 }
 
+// IsPodDisruptionBudgetEnabled checks if a PodDisruptionBudget should be created for the Vault pods
+func (spec *VaultSpec) IsPodDisruptionBudgetEnabled() bool {
+	if spec.Size < 2 {
+		return false
+	}
+	if spec.PodDisruptionBudget != nil && spec.PodDisruptionBudget.Enabled != nil {
+		return *spec.PodDisruptionBudget.Enabled
+	}
+	// A two member Raft cluster loses its quorum with either member, a budget would block every node drain
+	return !spec.isTwoMemberRaft()
+}
+
+// isTwoMemberRaft checks if the Vault instances form a Raft cluster of two members
+func (spec *VaultSpec) isTwoMemberRaft() bool {
+	return spec.Size == 2 && (spec.IsRaftStorage() || spec.IsRaftHAStorage())
+}
+
+// IsPodDisruptionBudgetQuorumLost checks if the PodDisruptionBudget can't preserve the Raft quorum,
+// it keeps one instance of a two member Raft cluster running then
+func (spec *VaultSpec) IsPodDisruptionBudgetQuorumLost() bool {
+	config := spec.PodDisruptionBudget
+	return spec.isTwoMemberRaft() && (config == nil || (config.MinAvailable == nil && config.MaxUnavailable == nil))
+}
+
+// IsMainServiceActiveOnly checks if the main Vault Service selects only the active Vault instance
+func (spec *VaultSpec) IsMainServiceActiveOnly() bool {
+	return spec.ServiceRegistrationEnabled && spec.ServiceTarget != ServiceTargetAll
+}
+
+// GetAPIPort returns the port of the Vault API, from ServicePorts or the address of the tcp listener in Config
+func (spec *VaultSpec) GetAPIPort() int32 {
+	if port, ok := spec.ServicePorts[spec.GetAPIPortName()]; ok {
+		return port
+	}
+	if port := spec.getListenerPort("address"); port != 0 {
+		return port
+	}
+	return 8200
+}
+
+// GetClusterPort returns the port of the Vault cluster, from ServicePorts or the cluster_address of the tcp listener
+// in Config, Vault listens on the port after the API port otherwise
+func (spec *VaultSpec) GetClusterPort() int32 {
+	if port, ok := spec.ServicePorts["cluster-port"]; ok {
+		return port
+	}
+	if port := spec.getListenerPort("cluster_address"); port != 0 {
+		return port
+	}
+	return spec.GetAPIPort() + 1
+}
+
+func (spec *VaultSpec) getListenerPort(key string) int32 {
+	tcp := cast.ToStringMap(spec.getListener()["tcp"])
+	_, port, err := net.SplitHostPort(cast.ToString(tcp[key]))
+	if err != nil {
+		return 0
+	}
+	return cast.ToInt32(port)
+}
+
 // GetVaultLabels returns the Vault Pod, Secret and ConfigMap Labels
 func (spec *VaultSpec) GetVaultLabels() map[string]string {
 	if spec.VaultLabels == nil {
@@ -627,11 +855,76 @@ func (spec *VaultSpec) ExternalConfigJSON() []byte {
 	return spec.ExternalConfig.Raw
 }
 
+// AllowsConfigResourcesFrom returns true if configuration resources in the given namespace may reference this Vault
+func (vault *Vault) AllowsConfigResourcesFrom(namespace string) bool {
+	if namespace == vault.Namespace {
+		return true
+	}
+	for _, ns := range vault.Spec.ConfigResourceNamespaces {
+		if ns == "*" || ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsTransitUnsealFrom returns true if Vault resources in the given namespace may auto-unseal with this Vault
+func (vault *Vault) AllowsTransitUnsealFrom(namespace string) bool {
+	if namespace == vault.Namespace {
+		return true
+	}
+	for _, ns := range vault.Spec.TransitUnsealNamespaces {
+		if ns == "*" || ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOperatorConfigurer returns true if the ExternalConfig is applied by the operator itself
+// instead of the configurer Deployment
+func (spec *VaultSpec) IsOperatorConfigurer() bool {
+	return spec.ConfigurerMode == ConfigurerModeOperator
+}
+
 // IsAutoUnseal checks if auto-unseal is configured
 func (spec *VaultSpec) IsAutoUnseal() bool {
 	config := spec.GetVaultConfig()
 	_, ok := config["seal"]
-	return ok
+	return ok || spec.GetSealType() != SealTypeShamir
+}
+
+// GetSealType returns the type of the enabled seal of Vault, from the config or rendered
+// from the unseal backend with SealMigration
+func (spec *VaultSpec) GetSealType() string {
+	seals := cast.ToStringMap(spec.GetVaultConfig()["seal"])
+	if len(seals) == 0 {
+		sealType, _ := spec.UnsealConfig.renderedSeal(spec.UnsealConfig.Options.SealMigration)
+		return sealType
+	}
+
+	types := make([]string, 0, len(seals))
+	for sealType, config := range seals {
+		if !cast.ToBool(cast.ToStringMap(config)["disabled"]) {
+			types = append(types, sealType)
+		}
+	}
+	if len(types) == 0 {
+		return SealTypeShamir
+	}
+	sort.Strings(types)
+	return types[0]
+}
+
+// GetRenderedSeal returns the seal stanza rendered by the operator from the unseal backend,
+// it is nil when the config has a seal stanza, SealMigration is disabled or the backend has no seal,
+// the transit stanza of a VaultRef is rendered by the operator from the referenced Vault instead
+func (spec *VaultSpec) GetRenderedSeal() map[string]string {
+	if _, ok := spec.GetVaultConfig()["seal"]; ok {
+		return nil
+	}
+	_, config := spec.UnsealConfig.renderedSeal(spec.UnsealConfig.Options.SealMigration)
+	return config
 }
 
 // IsRaftStorage checks if raft storage is configured
@@ -649,12 +942,387 @@ func (spec *VaultSpec) IsRaftBootstrapFollower() bool {
 	return spec.RaftLeaderAddress != "" && spec.RaftLeaderAddress != "self"
 }
 
+const (
+	// ConfigurerModeDeployment runs the Bank Vaults Configurer as a Deployment
+	ConfigurerModeDeployment = "deployment"
+	// ConfigurerModeOperator makes the operator apply the ExternalConfig through the Vault API
+	ConfigurerModeOperator = "operator"
+
+	// ConfigurationAppliedCondition reports whether the ExternalConfig has been applied by the operator
+	ConfigurationAppliedCondition v1.ComponentConditionType = "ConfigurationApplied"
+	// ConfigurationInSyncCondition reports whether Vault still matches the ExternalConfig
+	ConfigurationInSyncCondition v1.ComponentConditionType = "ConfigurationInSync"
+	// KeysRotatedCondition reports whether the last rotation of the unseal keys or the root token succeeded
+	KeysRotatedCondition v1.ComponentConditionType = "KeysRotated"
+	// UnsealedCondition reports whether every Vault pod has been unsealed by the operator
+	UnsealedCondition v1.ComponentConditionType = "Unsealed"
+	// KubernetesAuthConfiguredCondition reports whether the KubernetesAuth has been applied by the operator
+	KubernetesAuthConfiguredCondition v1.ComponentConditionType = "KubernetesAuthConfigured"
+	// OperatorIdentityCondition reports whether the operator authenticates to Vault with its own identity
+	OperatorIdentityCondition v1.ComponentConditionType = "OperatorIdentityBootstrapped"
+	// AuthDelegatorBoundCondition reports whether the Vault ServiceAccount may review ServiceAccount tokens
+	AuthDelegatorBoundCondition v1.ComponentConditionType = "AuthDelegatorBound"
+	// EmergencySealedCondition reports whether the Vault pods are kept sealed by Spec.EmergencySeal
+	EmergencySealedCondition v1.ComponentConditionType = "EmergencySealed"
+
+	defaultDriftDetectionInterval = 5 * time.Minute
+)
+
+// DriftDetection configures the periodic comparison of Vault with the ExternalConfig
+type DriftDetection struct {
+	// Interval between two checks
+	// default: 5m
+	Interval string `json:"interval,omitempty"`
+
+	// Enforce reapplies the ExternalConfig when drift is detected
+	// default: false
+	Enforce bool `json:"enforce,omitempty"`
+}
+
+// GetInterval returns the interval between two drift checks
+func (d *DriftDetection) GetInterval() time.Duration {
+	interval, err := time.ParseDuration(d.Interval)
+	if err != nil || interval <= 0 {
+		return defaultDriftDetectionInterval
+	}
+	return interval
+}
+
+// KubernetesAuthConfig configures the Kubernetes auth method of Vault
+type KubernetesAuthConfig struct {
+	// Path of the auth method
+	// default: kubernetes
+	Path string `json:"path,omitempty"`
+	// Host of the Kubernetes API as reached by Vault
+	// default: the host of the in-cluster config of the operator
+	Host string `json:"host,omitempty"`
+	// Issuer of the ServiceAccount tokens
+	// default: the issuer of the ServiceAccount token of the operator
+	Issuer string `json:"issuer,omitempty"`
+	// Roles bind ServiceAccounts to Vault policies, roles removed from this list are kept in Vault
+	// default:
+	Roles []KubernetesAuthRole `json:"roles,omitempty"`
+}
+
+// KubernetesAuthRole binds ServiceAccounts to Vault policies
+type KubernetesAuthRole struct {
+	Name string `json:"name"`
+	// BoundServiceAccountNames may contain "*" to allow every ServiceAccount of the bound namespaces
+	BoundServiceAccountNames []string `json:"boundServiceAccountNames"`
+	// BoundServiceAccountNamespaces may contain "*" to allow every namespace
+	BoundServiceAccountNamespaces []string `json:"boundServiceAccountNamespaces"`
+	// Policies of the tokens issued with the role
+	// default:
+	Policies []string `json:"policies,omitempty"`
+	// TTL of the tokens issued with the role
+	// default: the default lease TTL of the auth method
+	TTL string `json:"ttl,omitempty"`
+	// Audience the ServiceAccount tokens have to be issued for
+	// default: any audience
+	Audience string `json:"audience,omitempty"`
+}
+
+// GetPath returns the path of the Kubernetes auth method
+func (k *KubernetesAuthConfig) GetPath() string {
+	if path := strings.Trim(k.Path, "/"); path != "" {
+		return path
+	}
+	return "kubernetes"
+}
+
+// OperatorIdentityConfig configures the Kubernetes auth method the operator authenticates to Vault with
+type OperatorIdentityConfig struct {
+	// Path of the Kubernetes auth method of the operator
+	// default: vault-operator
+	Path string `json:"path,omitempty"`
+	// ServiceAccountName of the operator
+	// default: the ServiceAccount of the token of the operator
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// ServiceAccountNamespace of the operator
+	// default: the namespace of the token of the operator
+	ServiceAccountNamespace string `json:"serviceAccountNamespace,omitempty"`
+	// Policies granted to the operator besides its own vault-operator policy, which only covers the policies
+	// and the mounts of the ExternalConfig, the KubernetesAuth and the ConfigResourceNamespaces, for example
+	// for the configuration resources of the namespace of the Vault
+	// default:
+	Policies []string `json:"policies,omitempty"`
+}
+
+// GetPath returns the path of the Kubernetes auth method of the operator
+func (o *OperatorIdentityConfig) GetPath() string {
+	if path := strings.Trim(o.Path, "/"); path != "" {
+		return path
+	}
+	return "vault-operator"
+}
+
+// InitStoresRootToken returns true if the root token is stored at init, the operator needs it once
+// to bootstrap its identity even if StoreRootToken is false
+func (spec *VaultSpec) InitStoresRootToken() bool {
+	storeRootToken := spec.UnsealConfig.Options.StoreRootToken
+	return storeRootToken == nil || *storeRootToken || spec.OperatorIdentity != nil
+}
+
+// RemediationAction is the action taken on an unhealthy Vault pod
+type RemediationAction string
+
+const (
+	// RemediationRestart deletes the pod, the StatefulSet recreates it
+	RemediationRestart RemediationAction = "Restart"
+	// RemediationRaftRejoin removes the pod from the Raft cluster and recreates it with an empty volume,
+	// so it joins the cluster again, the leader is never rejoined
+	RemediationRaftRejoin RemediationAction = "RaftRejoin"
+	// RemediationCordon cordons the node of the pod and deletes the pod, so it is scheduled elsewhere
+	RemediationCordon RemediationAction = "Cordon"
+
+	defaultRemediationAfter  = 10 * time.Minute
+	defaultRemediationWindow = time.Hour
+)
+
+// RemediationPolicy configures the actions taken on Vault pods which stay unhealthy
+type RemediationPolicy struct {
+	// After is how long a pod has to stay sealed, uninitialised or unreachable before it is remediated
+	// default: 10m
+	After string `json:"after,omitempty"`
+	// Action taken on the unhealthy pods
+	// default: Restart
+	// +kubebuilder:validation:Enum=Restart;RaftRejoin;Cordon
+	Action RemediationAction `json:"action,omitempty"`
+	// MaxActions is the number of remediations allowed within the Window, further unhealthy pods are only reported
+	// default: 1
+	MaxActions int `json:"maxActions,omitempty"`
+	// Window of the MaxActions rate limit
+	// default: 1h
+	Window string `json:"window,omitempty"`
+	// Paused stops the remediation of this Vault, the unhealthy pods are still reported
+	// default: false
+	Paused bool `json:"paused,omitempty"`
+}
+
+// GetAfter returns how long a pod has to stay unhealthy before it is remediated
+func (p *RemediationPolicy) GetAfter() time.Duration {
+	after, err := time.ParseDuration(p.After)
+	if err != nil || after <= 0 {
+		return defaultRemediationAfter
+	}
+	return after
+}
+
+// GetAction returns the action taken on the unhealthy pods
+func (p *RemediationPolicy) GetAction() RemediationAction {
+	if p.Action == "" {
+		return RemediationRestart
+	}
+	return p.Action
+}
+
+// GetMaxActions returns the number of remediations allowed within the window
+func (p *RemediationPolicy) GetMaxActions() int {
+	if p.MaxActions <= 0 {
+		return 1
+	}
+	return p.MaxActions
+}
+
+// GetWindow returns the window of the rate limit
+func (p *RemediationPolicy) GetWindow() time.Duration {
+	window, err := time.ParseDuration(p.Window)
+	if err != nil || window <= 0 {
+		return defaultRemediationWindow
+	}
+	return window
+}
+
 // VaultStatus defines the observed state of Vault
 type VaultStatus struct {
 	// Important: Run "make generate-code" to regenerate code after modifying this file
 	Nodes      []string                `json:"nodes"`
 	Leader     string                  `json:"leader"`
 	Conditions []v1.ComponentCondition `json:"conditions,omitempty"`
+
+	// PodIPs are the IPs of the Vault pods, added to the certificate generated by the operator
+	PodIPs []string `json:"podIPs,omitempty"`
+
+	// GatewayObjects lists the Gateway API objects created by the operator as Kind/name, they are removed when not needed
+	GatewayObjects []string `json:"gatewayObjects,omitempty"`
+
+	// ConfigurationHash is the SHA256 hash of the ExternalConfig last applied by the operator
+	ConfigurationHash string `json:"configurationHash,omitempty"`
+
+	// OperatorIdentityHash is the SHA256 hash of the identity of the operator last bootstrapped in Vault
+	OperatorIdentityHash string `json:"operatorIdentityHash,omitempty"`
+
+	// KubernetesAuthHash is the SHA256 hash of the Kubernetes auth method configuration last applied by the operator
+	KubernetesAuthHash string `json:"kubernetesAuthHash,omitempty"`
+
+	// Drift lists the differences found between Vault and the ExternalConfig by the last drift check
+	Drift []string `json:"drift,omitempty"`
+
+	// LastDriftCheckTime is the time of the last drift check
+	LastDriftCheckTime *metav1.Time `json:"lastDriftCheckTime,omitempty"`
+
+	// Seal is the seal of the initialised Vault, as last recorded by the operator
+	Seal *SealStatus `json:"seal,omitempty"`
+
+	// SealMigration reports the last migration between unseal backends
+	SealMigration *SealMigrationStatus `json:"sealMigration,omitempty"`
+
+	// KeyRotation reports the last rotations of the unseal keys and the root token
+	KeyRotation *KeyRotationStatus `json:"keyRotation,omitempty"`
+
+	// RecoveryKeys reports the recovery keys of an auto-unsealed Vault
+	RecoveryKeys *RecoveryKeysStatus `json:"recoveryKeys,omitempty"`
+
+	// Remediation reports the unhealthy pods and the remediations taken by the operator
+	Remediation *RemediationStatus `json:"remediation,omitempty"`
+}
+
+// RemediationStatus reports the unhealthy Vault pods and the recent remediations
+type RemediationStatus struct {
+	// UnhealthyPods are the pods found sealed, uninitialised or unreachable by the last health check
+	UnhealthyPods []UnhealthyPod `json:"unhealthyPods,omitempty"`
+	// Actions are the remediations taken within the rate limit window
+	Actions []RemediationRecord `json:"actions,omitempty"`
+	// RemovedClaims are the volume claims deleted by a raft rejoin, the pods recreated before they were gone
+	// are restarted again, so the StatefulSet creates new claims
+	RemovedClaims []RemovedClaim `json:"removedClaims,omitempty"`
+}
+
+// RemovedClaim is a volume claim deleted by a raft rejoin
+type RemovedClaim struct {
+	Pod  string    `json:"pod"`
+	Name string    `json:"name"`
+	UID  types.UID `json:"uid"`
+}
+
+// UnhealthyPod is a Vault pod found unhealthy
+type UnhealthyPod struct {
+	Name string `json:"name"`
+	// Reason is why the pod is unhealthy: Sealed, Uninitialized or Unreachable
+	Reason string `json:"reason"`
+	// Since is the first health check which found the pod unhealthy for this reason
+	Since metav1.Time `json:"since"`
+}
+
+// RemediationRecord is a remediation taken by the operator
+type RemediationRecord struct {
+	Pod    string            `json:"pod"`
+	Action RemediationAction `json:"action"`
+	Reason string            `json:"reason"`
+	Time   metav1.Time       `json:"time"`
+}
+
+// RecoveryKeysStatus reports whether the recovery keys of an auto-unsealed Vault have been generated and where they are kept
+type RecoveryKeysStatus struct {
+	// Generated is true once Vault has been initialised with recovery keys
+	Generated bool `json:"generated"`
+	// Shares is the number of recovery keys generated by Vault
+	Shares int `json:"shares,omitempty"`
+	// Threshold is the number of recovery keys needed to rekey or to generate a root token
+	Threshold int `json:"threshold,omitempty"`
+	// Location is the namespace/name of the Kubernetes Secret keeping the recovery keys, or the unseal backend
+	Location string `json:"location,omitempty"`
+	// Stored is the number of recovery keys found at the Location, it is only counted in Kubernetes Secrets
+	Stored int `json:"stored,omitempty"`
+}
+
+// KeyRotationStatus reports the last rotations of the unseal keys and the root token
+type KeyRotationStatus struct {
+	// LastRekeyTime is the time of the last rekey, or of the first check for periodic rekeys
+	LastRekeyTime *metav1.Time `json:"lastRekeyTime,omitempty"`
+	// LastRootTokenTime is the time of the last root token regeneration, or of the first check for periodic ones
+	LastRootTokenTime *metav1.Time `json:"lastRootTokenTime,omitempty"`
+	// RootTokenGeneration is the last KeyRotation.RootTokenGeneration handled by the operator
+	RootTokenGeneration string `json:"rootTokenGeneration,omitempty"`
+}
+
+// SealTypeShamir is the seal type of Vault unsealed with key shares
+const SealTypeShamir = "shamir"
+
+// SealStatus describes where the keys of Vault are stored and how Vault is sealed
+type SealStatus struct {
+	// Backend is the unseal backend storing the keys and the root token, see UnsealConfig.Backend()
+	Backend string `json:"backend"`
+	// KeysSecret is the namespace/name of the Secret holding the keys with the kubernetes backend
+	KeysSecret string `json:"keysSecret,omitempty"`
+	// Type is the seal type of Vault, shamir or the type of the enabled seal stanza
+	Type string `json:"type"`
+	// Config is the seal stanza rendered by the operator, seal stanzas of the Vault config are not recorded
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// SealMigrationPhase is the phase of a seal migration
+type SealMigrationPhase string
+
+const (
+	// SealMigrationRestarting is the phase where the Vault pods are restarted and unsealed one at a time
+	SealMigrationRestarting SealMigrationPhase = "Restarting"
+	// SealMigrationMovingKeys is the phase where the keys are moved to the new unseal backend
+	SealMigrationMovingKeys SealMigrationPhase = "MovingKeys"
+	// SealMigrationCompleted is the phase of a finished migration
+	SealMigrationCompleted SealMigrationPhase = "Completed"
+	// SealMigrationFailed is the phase of a migration which needs a change in the spec to continue
+	SealMigrationFailed SealMigrationPhase = "Failed"
+)
+
+// SealMigrationStatus reports the progress of a migration between unseal backends
+type SealMigrationStatus struct {
+	Phase SealMigrationPhase `json:"phase"`
+	// Target is the seal the Vault is migrated to
+	Target SealStatus `json:"target"`
+	// MigratedPods lists the pods restarted and unsealed with the new seal
+	MigratedPods []string     `json:"migratedPods,omitempty"`
+	Message      string       `json:"message,omitempty"`
+	StartTime    *metav1.Time `json:"startTime,omitempty"`
+}
+
+// InProgress returns true if the migration still holds back the rolling update of the Vault pods,
+// a failed migration never started, so the pods are updated as without a migration
+func (status *SealMigrationStatus) InProgress() bool {
+	return status != nil && status.Phase != SealMigrationCompleted && status.Phase != SealMigrationFailed
+}
+
+// GetCondition returns the condition with the given type, or nil if it is not present
+func (status *VaultStatus) GetCondition(conditionType v1.ComponentConditionType) *v1.ComponentCondition {
+	return getCondition(status.Conditions, conditionType)
+}
+
+// SetCondition adds the condition or replaces the one with the same type, and returns true if the status changed
+func (status *VaultStatus) SetCondition(condition v1.ComponentCondition) bool {
+	return setCondition(&status.Conditions, condition)
+}
+
+// RemoveCondition removes the condition with the given type, and returns true if the status changed
+func (status *VaultStatus) RemoveCondition(conditionType v1.ComponentConditionType) bool {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == conditionType {
+			status.Conditions = append(status.Conditions[:i], status.Conditions[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func getCondition(conditions []v1.ComponentCondition, conditionType v1.ComponentConditionType) *v1.ComponentCondition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+func setCondition(conditions *[]v1.ComponentCondition, condition v1.ComponentCondition) bool {
+	current := getCondition(*conditions, condition.Type)
+	if current == nil {
+		*conditions = append(*conditions, condition)
+		return true
+	}
+	if *current == condition {
+		return false
+	}
+	*current = condition
+	return true
 }
 
 // UnsealOptions represents the common options to all unsealing backends
@@ -663,6 +1331,83 @@ type UnsealOptions struct {
 	StoreRootToken  *bool `json:"storeRootToken,omitempty"`
 	SecretThreshold *uint `json:"secretThreshold,omitempty"`
 	SecretShares    *uint `json:"secretShares,omitempty"`
+
+	// RecoveryThreshold is the number of recovery keys needed to rekey or to generate a root token
+	// of an auto-unsealed Vault
+	// default: SecretThreshold
+	RecoveryThreshold *uint `json:"recoveryThreshold,omitempty"`
+	// RecoveryShares is the number of recovery keys generated by an auto-unsealed Vault
+	// default: SecretShares
+	RecoveryShares *uint `json:"recoveryShares,omitempty"`
+
+	// SealMigration renders the seal stanza of Vault from the unseal backend, unless the config has one,
+	// and migrates an initialised Vault pod by pod when the unseal backend changes,
+	// the keys have to be kept in a Kubernetes Secret before and after the migration
+	// default: false
+	SealMigration bool `json:"sealMigration,omitempty"`
+
+	// Rotation lets the operator rekey the unseal keys and regenerate the root token,
+	// it needs the keys in a Kubernetes Secret, it is rejected when custodians hold them
+	Rotation *KeyRotation `json:"rotation,omitempty"`
+}
+
+// GetSecretShares returns the number of unseal key shares
+func (o *UnsealOptions) GetSecretShares() int {
+	if o.SecretShares == nil || *o.SecretShares == 0 {
+		return 5
+	}
+	return int(*o.SecretShares)
+}
+
+// GetSecretThreshold returns the number of unseal key shares required to unseal Vault
+func (o *UnsealOptions) GetSecretThreshold() int {
+	if o.SecretThreshold == nil || *o.SecretThreshold == 0 {
+		return 3
+	}
+	return int(*o.SecretThreshold)
+}
+
+// GetRecoveryShares returns the number of recovery key shares of an auto-unsealed Vault
+func (o *UnsealOptions) GetRecoveryShares() int {
+	if o.RecoveryShares == nil || *o.RecoveryShares == 0 {
+		return o.GetSecretShares()
+	}
+	return int(*o.RecoveryShares)
+}
+
+// GetRecoveryThreshold returns the number of recovery key shares required to rekey an auto-unsealed Vault
+func (o *UnsealOptions) GetRecoveryThreshold() int {
+	if o.RecoveryThreshold == nil || *o.RecoveryThreshold == 0 {
+		return o.GetSecretThreshold()
+	}
+	return int(*o.RecoveryThreshold)
+}
+
+// KeyRotation configures the rotation of the unseal keys, or the recovery keys of an auto seal, and of the root token
+type KeyRotation struct {
+	// RekeyInterval between two rekeys, the keys are also rekeyed when SecretShares or SecretThreshold change
+	// default: no periodic rekey
+	RekeyInterval string `json:"rekeyInterval,omitempty"`
+
+	// RootTokenInterval between two regenerations of the root token, the previous root token is revoked
+	// default: no periodic regeneration
+	RootTokenInterval string `json:"rootTokenInterval,omitempty"`
+
+	// RootTokenGeneration regenerates the root token and revokes the previous one whenever its value changes
+	RootTokenGeneration string `json:"rootTokenGeneration,omitempty"`
+}
+
+// GetRekeyInterval returns the interval between two rekeys, zero if the keys are not rekeyed periodically
+func (r *KeyRotation) GetRekeyInterval() time.Duration {
+	interval, _ := time.ParseDuration(r.RekeyInterval)
+	return max(interval, 0)
+}
+
+// GetRootTokenInterval returns the interval between two root token regenerations, zero if the root token
+// is not regenerated periodically
+func (r *KeyRotation) GetRootTokenInterval() time.Duration {
+	interval, _ := time.ParseDuration(r.RootTokenInterval)
+	return max(interval, 0)
 }
 
 // UnsealConfig represents the UnsealConfig field of a VaultSpec Kubernetes object
@@ -676,6 +1421,14 @@ type UnsealConfig struct {
 	OCI        *OCIUnsealConfig       `json:"oci,omitempty"`
 	Vault      *VaultUnsealConfig     `json:"vault,omitempty"`
 	HSM        *HSMUnsealConfig       `json:"hsm,omitempty"`
+	// Custodians splits the unseal keys among custodians instead of storing them
+	Custodians *CustodianUnsealConfig `json:"custodians,omitempty"`
+	// RecoveryKeys keeps the recovery keys of an auto-unsealed Vault in their own Kubernetes Secret,
+	// apart from the root token, it needs the Kubernetes unseal backend
+	RecoveryKeys *RecoveryKeysConfig `json:"recoveryKeys,omitempty"`
+	// VaultRef auto-unseals Vault with the transit secret engine of another Vault resource,
+	// the recovery keys and the root token are stored like in the default Kubernetes mode
+	VaultRef *VaultRefUnsealConfig `json:"vaultRef,omitempty"`
 }
 
 // ToArgs returns the UnsealConfig as and argument array for bank-vaults
@@ -687,18 +1440,29 @@ func (usc *UnsealConfig) ToArgs(vault *Vault) []string {
 		args = append(args, "--pre-flight-checks=false")
 	}
 	// StoreRootToken is true by default
-	if usc.Options.StoreRootToken != nil && !*usc.Options.StoreRootToken {
+	if !vault.Spec.InitStoresRootToken() {
 		args = append(args, "--store-root-token=false")
 	}
 
+	// The unsealer generates as many recovery keys as secret shares for an auto-unsealed Vault
+	secretShares, secretThreshold := usc.Options.SecretShares, usc.Options.SecretThreshold
+	if vault.Spec.IsAutoUnseal() {
+		if usc.Options.RecoveryShares != nil && *usc.Options.RecoveryShares > 0 {
+			secretShares = usc.Options.RecoveryShares
+		}
+		if usc.Options.RecoveryThreshold != nil && *usc.Options.RecoveryThreshold > 0 {
+			secretThreshold = usc.Options.RecoveryThreshold
+		}
+	}
+
 	// SecretShares is 5 by default
-	if usc.Options.SecretShares != nil && *usc.Options.SecretShares > 0 {
-		args = append(args, "--secret-shares", fmt.Sprint(*usc.Options.SecretShares))
+	if secretShares != nil && *secretShares > 0 {
+		args = append(args, "--secret-shares", fmt.Sprint(*secretShares))
 	}
 
 	// SecretThreshold is 3 by default
-	if usc.Options.SecretThreshold != nil && *usc.Options.SecretThreshold > 0 {
-		args = append(args, "--secret-threshold", fmt.Sprint(*usc.Options.SecretThreshold))
+	if secretThreshold != nil && *secretThreshold > 0 {
+		args = append(args, "--secret-threshold", fmt.Sprint(*secretThreshold))
 	}
 
 	if usc.Google != nil {
@@ -787,7 +1551,12 @@ func (usc *UnsealConfig) ToArgs(vault *Vault) []string {
 			usc.Vault.UnsealKeysPath,
 		)
 
-		if usc.Vault.Token != "" {
+		if usc.Vault.TokenSecretRef != nil {
+			args = append(args,
+				"--vault-token-path",
+				VaultUnsealTokenMountPath+"/token",
+			)
+		} else if usc.Vault.Token != "" {
 			args = append(args,
 				"--vault-token",
 				usc.Vault.Token,
@@ -822,7 +1591,7 @@ func (usc *UnsealConfig) ToArgs(vault *Vault) []string {
 			usc.HSM.KeyLabel,
 		)
 
-		if usc.HSM.Pin != "" {
+		if usc.HSM.Pin != "" && usc.HSM.PinSecretRef == nil {
 			args = append(args,
 				"--hsm-pin",
 				usc.HSM.Pin,
@@ -853,16 +1622,16 @@ func (usc *UnsealConfig) ToArgs(vault *Vault) []string {
 				strings.Join(secretLabels, ","),
 			)
 		}
+	} else if usc.IsEncrypted() {
+		// The key decryptor sidecar keeps the decrypted keys in memory next to the unsealer and the configurer
+		args = append(args,
+			"--mode",
+			"file",
+			"--file-path",
+			DecryptedKeysMountPath,
+		)
 	} else {
-		secretNamespace := vault.Namespace
-		if usc.Kubernetes.SecretNamespace != "" {
-			secretNamespace = usc.Kubernetes.SecretNamespace
-		}
-
-		secretName := vault.Name + "-unseal-keys"
-		if usc.Kubernetes.SecretName != "" {
-			secretName = usc.Kubernetes.SecretName
-		}
+		secretNamespace, secretName := usc.KubernetesSecret(vault)
 
 		var secretLabels []string
 		for k, v := range vault.LabelsForVault() {
@@ -886,6 +1655,127 @@ func (usc *UnsealConfig) ToArgs(vault *Vault) []string {
 	return args
 }
 
+// IsKubernetes returns true if the unseal keys and root token are stored in a Kubernetes Secret in plain form,
+// which is the default mode
+func (usc *UnsealConfig) IsKubernetes() bool {
+	return usc.Google == nil && usc.Azure == nil && usc.OCI == nil && usc.AWS == nil &&
+		usc.Alibaba == nil && usc.Vault == nil && usc.HSM == nil && usc.Custodians == nil
+}
+
+// IsEncrypted returns true if the unseal keys and root token are stored encrypted in a Kubernetes Secret
+func (usc *UnsealConfig) IsEncrypted() bool {
+	return usc.IsKubernetes() && usc.Kubernetes.Encryption != nil
+}
+
+// OperatorUnseals returns true if the operator initialises and unseals Vault in place of the unsealer
+func (usc *UnsealConfig) OperatorUnseals() bool {
+	return usc.Custodians != nil
+}
+
+// KubernetesSecret returns the namespace and name of the Kubernetes Secret holding the unseal keys and root token
+func (usc *UnsealConfig) KubernetesSecret(vault *Vault) (string, string) {
+	secretNamespace := vault.Namespace
+	if usc.Kubernetes.SecretNamespace != "" {
+		secretNamespace = usc.Kubernetes.SecretNamespace
+	}
+
+	secretName := vault.Name + "-unseal-keys"
+	if usc.Kubernetes.SecretName != "" {
+		secretName = usc.Kubernetes.SecretName
+	}
+
+	return secretNamespace, secretName
+}
+
+// RecoveryKeysSecret returns the namespace and name of the Kubernetes Secret keeping the recovery keys
+func (usc *UnsealConfig) RecoveryKeysSecret(vault *Vault) (string, string) {
+	if usc.RecoveryKeys == nil {
+		return usc.KubernetesSecret(vault)
+	}
+
+	secretNamespace := vault.Namespace
+	if usc.RecoveryKeys.SecretNamespace != "" {
+		secretNamespace = usc.RecoveryKeys.SecretNamespace
+	}
+
+	secretName := vault.Name + "-recovery-keys"
+	if usc.RecoveryKeys.SecretName != "" {
+		secretName = usc.RecoveryKeys.SecretName
+	}
+
+	return secretNamespace, secretName
+}
+
+// PlaintextSecrets returns the sensitive fields set in plain text, instead of through a Secret reference
+func (usc *UnsealConfig) PlaintextSecrets() []string {
+	var fields []string
+	if usc.HSM != nil && usc.HSM.Pin != "" && usc.HSM.PinSecretRef == nil {
+		fields = append(fields, "unsealConfig.hsm.pin")
+	}
+	if usc.Vault != nil && usc.Vault.Token != "" && usc.Vault.TokenSecretRef == nil {
+		fields = append(fields, "unsealConfig.vault.token")
+	}
+	return fields
+}
+
+// Backend returns the name of the unseal backend storing the keys and the root token
+func (usc *UnsealConfig) Backend() string {
+	switch {
+	case usc.Google != nil:
+		return "google"
+	case usc.Azure != nil:
+		return "azure"
+	case usc.OCI != nil:
+		return "oci"
+	case usc.AWS != nil:
+		return "aws"
+	case usc.Alibaba != nil:
+		return "alibaba"
+	case usc.Vault != nil:
+		return "vault"
+	case usc.HSM != nil:
+		return "hsm"
+	case usc.Custodians != nil:
+		return "custodians"
+	}
+	return "kubernetes"
+}
+
+// renderedSeal returns the type and the stanza of the Vault seal matching the unseal backend,
+// backends without a matching seal, or a disabled rendering, fall back to Shamir
+func (usc *UnsealConfig) renderedSeal(enabled bool) (string, map[string]string) {
+	switch {
+	case usc.VaultRef != nil:
+		// The operator renders the stanza, it depends on the referenced Vault
+		return "transit", nil
+	case !enabled:
+	case usc.Google != nil:
+		return "gcpckms", map[string]string{
+			"project":    usc.Google.KMSProject,
+			"region":     usc.Google.KMSLocation,
+			"key_ring":   usc.Google.KMSKeyRing,
+			"crypto_key": usc.Google.KMSCryptoKey,
+		}
+	case usc.AWS != nil:
+		config := map[string]string{"kms_key_id": usc.AWS.KMSKeyID}
+		if usc.AWS.KMSRegion != "" {
+			config["region"] = usc.AWS.KMSRegion
+		}
+		return "awskms", config
+	case usc.Vault != nil && usc.Vault.TransitKeyName != "":
+		mountPath := usc.Vault.TransitMountPath
+		if mountPath == "" {
+			mountPath = "transit/"
+		}
+		return "transit", map[string]string{
+			"address":    usc.Vault.Address,
+			"key_name":   usc.Vault.TransitKeyName,
+			"mount_path": mountPath,
+		}
+	}
+	return SealTypeShamir, nil
+}
+
 // HSMDaemonNeeded returns if the unsealing mechanism needs a HSM Daemon present
 func (usc *UnsealConfig) HSMDaemonNeeded() bool {
 	return usc.HSM != nil && usc.HSM.Daemon
@@ -895,6 +1785,25 @@ func (usc *UnsealConfig) HSMDaemonNeeded() bool {
 type KubernetesUnsealConfig struct {
 	SecretNamespace string `json:"secretNamespace,omitempty"`
 	SecretName      string `json:"secretName,omitempty"`
+	// Encryption encrypts the unseal keys and the root token before they are stored in the Secret,
+	// the operator initialises Vault, and a key decryptor sidecar decrypts them for the unsealer and the configurer
+	Encryption *UnsealKeyEncryption `json:"encryption,omitempty"`
+}
+
+// DecryptedKeysMountPath is the in-memory directory where the key decryptor sidecar keeps the decrypted keys
+const DecryptedKeysMountPath = "/vault/decrypted-keys"
+
+// UnsealKeyEncryption holds the key encryption key of the unseal keys Secret, every value is encrypted
+// with its own data key, which is encrypted with the key encryption key
+type UnsealKeyEncryption struct {
+	// KEKSecretRef selects the key encryption key in a Secret, a base64 encoded 32 byte AES-256 key
+	KEKSecretRef *v1.SecretKeySelector `json:"kekSecretRef,omitempty"`
+	// KEKSecretNamespace is the namespace of KEKSecretRef, which should be kept apart from the unseal keys
+	// default: the namespace of the Vault
+	KEKSecretNamespace string `json:"kekSecretNamespace,omitempty"`
+	// KMSPluginSocket is the unix socket of a Kubernetes KMS v2 plugin, it has to be mounted into the operator Pod,
+	// the key decryptor sidecars mount it from the host, so the Vault Pods have to run on nodes with the plugin
+	KMSPluginSocket string `json:"kmsPluginSocket,omitempty"`
 }
 
 // GoogleUnsealConfig holds the parameters for Google KMS based unsealing
@@ -949,7 +1858,88 @@ type VaultUnsealConfig struct {
 	Role           string `json:"role,omitempty"`
 	AuthPath       string `json:"authPath,omitempty"`
 	TokenPath      string `json:"tokenPath,omitempty"`
-	Token          string `json:"token,omitempty"`
+	// Token is visible in the Pod arguments, use TokenSecretRef instead
+	Token string `json:"token,omitempty"`
+	// TokenSecretRef selects the token in a Secret, it is mounted as a file into the bank-vaults containers
+	TokenSecretRef *v1.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+	// TransitKeyName is the transit key of the seal stanza rendered with SealMigration,
+	// without it Vault keeps the Shamir seal
+	TransitKeyName string `json:"transitKeyName,omitempty"`
+	// TransitMountPath is the mount path of the transit secret engine
+	// default: transit/
+	TransitMountPath string `json:"transitMountPath,omitempty"`
+}
+
+// VaultUnsealTokenMountPath is the directory where the token of VaultUnsealConfig.TokenSecretRef is mounted
+const VaultUnsealTokenMountPath = "/vault/unseal-token"
+
+// RecoveryKeysConfig holds the Kubernetes Secret keeping the recovery keys
+type RecoveryKeysConfig struct {
+	// SecretNamespace of the Secret
+	// default: the namespace of the Vault
+	SecretNamespace string `json:"secretNamespace,omitempty"`
+	// SecretName of the Secret
+	// default: <name>-recovery-keys
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// VaultRefUnsealConfig holds the parameters for transit auto-unsealing with another Vault resource,
+// the operator enables the transit secret engine and the key on the referenced Vault, and creates
+// a token which may only use that key
+type VaultRefUnsealConfig struct {
+	// Name of the referenced Vault
+	Name string `json:"name"`
+	// Namespace of the referenced Vault
+	// default: the namespace of the Vault
+	Namespace string `json:"namespace,omitempty"`
+	// KeyName is the transit key encrypting the root key of the Vault
+	// default: <namespace>-<name>
+	KeyName string `json:"keyName,omitempty"`
+	// MountPath is the mount path of the transit secret engine on the referenced Vault
+	// default: transit/
+	MountPath string `json:"mountPath,omitempty"`
+}
+
+// EmergencySealAnnotation seals the Vault like Spec.EmergencySeal when it is set to "true"
+const EmergencySealAnnotation = "vault.banzaicloud.io/emergency-seal"
+
+// IsEmergencySealed returns true if the Vault pods have to be kept sealed
+func (vault *Vault) IsEmergencySealed() bool {
+	return vault.Spec.EmergencySeal || vault.Annotations[EmergencySealAnnotation] == "true"
+}
+
+// VaultRefUnsealMountPath is the directory where the transit token and the CA of the referenced Vault are mounted
+const VaultRefUnsealMountPath = "/vault/transit-unseal"
+
+// TransitUnsealVault returns the namespace and name of the Vault referenced by UnsealConfig.VaultRef
+func (vault *Vault) TransitUnsealVault() (string, string) {
+	ref := vault.Spec.UnsealConfig.VaultRef
+	if ref.Namespace != "" {
+		return ref.Namespace, ref.Name
+	}
+	return vault.Namespace, ref.Name
+}
+
+// TransitUnsealKeyName returns the transit key of UnsealConfig.VaultRef
+func (vault *Vault) TransitUnsealKeyName() string {
+	if keyName := vault.Spec.UnsealConfig.VaultRef.KeyName; keyName != "" {
+		return keyName
+	}
+	return vault.Namespace + "-" + vault.Name
+}
+
+// TransitUnsealMountPath returns the mount path of the transit secret engine of UnsealConfig.VaultRef
+func (vault *Vault) TransitUnsealMountPath() string {
+	mountPath := vault.Spec.UnsealConfig.VaultRef.MountPath
+	if mountPath == "" {
+		return "transit/"
+	}
+	return strings.TrimSuffix(mountPath, "/") + "/"
+}
+
+// TransitUnsealSecretName returns the name of the Secret holding the transit token and the CA of UnsealConfig.VaultRef
+func (vault *Vault) TransitUnsealSecretName() string {
+	return vault.Name + "-transit-unseal"
 }
 
 // HSMUnsealConfig holds the parameters for remote HSM based unsealing
@@ -958,9 +1948,48 @@ type HSMUnsealConfig struct {
 	ModulePath string `json:"modulePath"`
 	SlotID     uint   `json:"slotId,omitempty"`
 	TokenLabel string `json:"tokenLabel,omitempty"`
+	// Pin is visible in the Pod arguments, use PinSecretRef instead
+	// +optional
+	Pin string `json:"pin"`
+	// PinSecretRef selects the pin in a Secret, it is passed to the bank-vaults containers as an environment variable
 	// +optional
-	Pin      string `json:"pin"`
-	KeyLabel string `json:"keyLabel"`
+	PinSecretRef *v1.SecretKeySelector `json:"pinSecretRef,omitempty"`
+	KeyLabel     string                `json:"keyLabel"`
+}
+
+// CustodianUnsealConfig holds the parameters for unsealing with key shares held by custodians,
+// every share is encrypted with the PGP key of its custodian, and Vault is unsealed by the operator
+// with the decrypted shares the custodians submit
+type CustodianUnsealConfig struct {
+	// Custodians receive one key share each, SecretThreshold of them are needed to unseal Vault
+	Custodians []Custodian `json:"custodians"`
+	// SubmissionSecretName is the Secret where the custodians submit their decrypted key shares,
+	// the operator wipes it once the shares are submitted to every Vault pod
+	// default: <name>-unseal-submission
+	SubmissionSecretName string `json:"submissionSecretName,omitempty"`
+}
+
+// Custodian holds one key share of Vault
+type Custodian struct {
+	// Name of the custodian, the encrypted key share is written to the <name>-unseal-key-<custodian> Secret
+	Name string `json:"name"`
+	// PGPKeySecretRef selects the base64 encoded PGP public key of the custodian in a Secret
+	PGPKeySecretRef *v1.SecretKeySelector `json:"pgpKeySecretRef,omitempty"`
+	// PGPKeyConfigMapRef selects the base64 encoded PGP public key of the custodian in a ConfigMap
+	PGPKeyConfigMapRef *v1.ConfigMapKeySelector `json:"pgpKeyConfigMapRef,omitempty"`
+}
+
+// CustodianKeySecretName returns the name of the Secret holding the encrypted key share of a custodian
+func (vault *Vault) CustodianKeySecretName(custodian string) string {
+	return vault.Name + "-unseal-key-" + custodian
+}
+
+// CustodianSubmissionSecretName returns the name of the Secret where the custodians submit their key shares
+func (vault *Vault) CustodianSubmissionSecretName() string {
+	if name := vault.Spec.UnsealConfig.Custodians.SubmissionSecretName; name != "" {
+		return name
+	}
+	return vault.Name + "-unseal-submission"
 }
 
 // CredentialsConfig configuration for a credentials file provided as a secret
@@ -983,6 +2012,123 @@ type Resources struct {
 type Ingress struct {
 	Annotations map[string]string `json:"annotations,omitempty"`
 	Spec        netv1.IngressSpec `json:"spec,omitempty"`
+
+	// StandbyHosts are routed to the <name>-standby Service, requires ServiceRegistrationEnabled
+	// default:
+	StandbyHosts []string `json:"standbyHosts,omitempty"`
+}
+
+const (
+	// ServiceTargetActive selects only the active Vault instance behind the main Vault Service
+	ServiceTargetActive = "active"
+	// ServiceTargetAll selects all the Vault instances behind the main Vault Service
+	ServiceTargetAll = "all"
+)
+
+const (
+	// GatewayModeHTTPRoute terminates TLS at the Gateway and re-encrypts the traffic towards Vault
+	GatewayModeHTTPRoute = "HTTPRoute"
+	// GatewayModeTLSRoute passes TLS through the Gateway to Vault
+	GatewayModeTLSRoute = "TLSRoute"
+)
+
+// RBAC configures the ServiceAccount and the RBAC resources created for Vault
+type RBAC struct {
+	// Create makes the operator create the ServiceAccount unless it already exists, an existing ServiceAccount
+	// is used as it is, without the ServiceAccountAnnotations, and a Role and RoleBinding allowing:
+	// - the Pod label updates needed by ServiceRegistrationEnabled
+	// - the unseal keys Secret access needed by the Kubernetes and HSM with Kubernetes unseal modes
+	// default: false
+	Create bool `json:"create,omitempty"`
+
+	// ServiceAccountAnnotations are added to the ServiceAccount, for example to set up workload identity
+	// with eks.amazonaws.com/role-arn, iam.gke.io/gcp-service-account or azure.workload.identity/client-id
+	// default:
+	ServiceAccountAnnotations map[string]string `json:"serviceAccountAnnotations,omitempty"`
+}
+
+// PodDisruptionBudget configures the PodDisruptionBudget of the Vault pods. The configurer pod has none:
+// it runs as a single replica, which a budget would either keep from every node drain or not protect at all,
+// and it reapplies the configuration when it is rescheduled.
+type PodDisruptionBudget struct {
+	// Enabled creates the PodDisruptionBudget, it is never created for single instance clusters,
+	// and only on request for two member Raft clusters, where it keeps one member running without a quorum
+	// default: true, false for two member Raft clusters
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// MinAvailable is the number or percentage of Vault pods that have to stay available, it overrides MaxUnavailable
+	// default:
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable is the number or percentage of Vault pods that can be evicted at the same time
+	// default: (Size-1)/2 with Raft storage to preserve the quorum, Size-1 otherwise
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// NetworkPolicy configures the NetworkPolicies of the Vault and the configurer pods
+type NetworkPolicy struct {
+	// Enabled creates NetworkPolicies which allow only the cluster port between the Vault pods,
+	// the API port from the Vault, the configurer and the operator pods and from APIPeers,
+	// and the API and metrics ports from the MonitoringNamespace
+	// default: false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// APIPeers are allowed to reach the Vault API as well, for example the namespace of the Ingress controller
+	// or the namespaces of the Vault clients
+	// default:
+	APIPeers []netv1.NetworkPolicyPeer `json:"apiPeers,omitempty"`
+
+	// MonitoringNamespace is allowed to scrape the metrics of the Vault and the configurer pods,
+	// including the /v1/sys/metrics endpoint of the Vault API
+	// default: the namespace of the Vault
+	MonitoringNamespace string `json:"monitoringNamespace,omitempty"`
+}
+
+// Gateway specification for exposing the Vault cluster through the Gateway API
+type Gateway struct {
+	// Mode selects the kind of the route:
+	// - "HTTPRoute": TLS is terminated at the Gateway, a BackendTLSPolicy makes the Gateway trust the Vault CA
+	// - "TLSRoute": TLS is passed through to Vault, the hostnames are added to the Vault certificate
+	// default: HTTPRoute
+	Mode string `json:"mode,omitempty"`
+
+	// ParentRefs are the Gateways the route attaches to
+	ParentRefs []GatewayParentReference `json:"parentRefs"`
+
+	// Hostnames of the route
+	// default: TLSAdditionalHosts without the IP addresses
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// StandbyHostnames get a separate route to the <name>-standby Service, requires ServiceRegistrationEnabled
+	// default:
+	StandbyHostnames []string `json:"standbyHostnames,omitempty"`
+
+	// Annotations of the route
+	// default:
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// GatewayParentReference identifies a Gateway, or one of its listeners, the route attaches to
+type GatewayParentReference struct {
+	// Name of the Gateway
+	Name string `json:"name"`
+
+	// Namespace of the Gateway
+	// default: the namespace of the Vault
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName is the name of the Gateway listener
+	// default:
+	SectionName string `json:"sectionName,omitempty"`
+
+	// Port of the Gateway listener
+	// default:
+	Port *int32 `json:"port,omitempty"`
+}
+
+// IsTLSRoute returns true if TLS is passed through the Gateway to Vault
+func (gateway *Gateway) IsTLSRoute() bool {
+	return gateway.Mode == GatewayModeTLSRoute
 }
 
 // +genclient
@@ -1007,6 +2153,12 @@ func (vault *Vault) ConfigJSON() ([]byte, error) {
 		return nil, err
 	}
 
+	if seals := vault.sealsForMigration(vault.Spec.GetRenderedSeal()); len(seals) != 0 {
+		if err := mergo.Merge(&config, map[string]interface{}{"seal": seals}); err != nil {
+			return nil, err
+		}
+	}
+
 	if vault.Spec.ServiceRegistrationEnabled && vault.Spec.HasHAStorage() {
 		serviceRegistration := map[string]interface{}{
 			"service_registration": map[string]interface{}{
@@ -1029,16 +2181,52 @@ func (vault *Vault) ConfigJSON() ([]byte, error) {
 	return configJSON, nil
 }
 
+// sealsForMigration returns the seal stanzas rendered by the operator, the previous seal is kept
+// with disabled semantics until the migration away from it completes
+func (vault *Vault) sealsForMigration(seal map[string]string) map[string]interface{} {
+	seals := map[string]interface{}{}
+	sealType := vault.Spec.GetSealType()
+	if seal != nil {
+		seals[sealType] = seal
+	}
+
+	previous := vault.Status.Seal
+	if vault.Status.SealMigration.InProgress() && previous != nil && previous.Config != nil && previous.Type != sealType {
+		disabled := map[string]string{"disabled": "true"}
+		for key, value := range previous.Config {
+			disabled[key] = value
+		}
+		seals[previous.Type] = disabled
+	}
+
+	return seals
+}
+
+// ActiveServiceName returns the name of the Service selecting the active Vault instance
+func (vault *Vault) ActiveServiceName() string {
+	return vault.Name + "-active"
+}
+
+// StandbyServiceName returns the name of the Service selecting the standby Vault instances
+func (vault *Vault) StandbyServiceName() string {
+	return vault.Name + "-standby"
+}
+
 // GetIngress the Ingress configuration for Vault if any
 func (vault *Vault) GetIngress() *Ingress {
 	if vault.Spec.Ingress != nil {
-		// Add the Vault Service as the backend if no rules are specified and there is no default backend
+		// Add the Vault Service as the backend if no rules are specified and there is no default backend,
+		// or the active Service if the main Service doesn't select only the active instance
 		if len(vault.Spec.Ingress.Spec.Rules) == 0 && vault.Spec.Ingress.Spec.DefaultBackend == nil {
+			serviceName := vault.Name
+			if vault.Spec.ServiceRegistrationEnabled && vault.Spec.ServiceTarget != ServiceTargetAll {
+				serviceName = vault.ActiveServiceName()
+			}
 			vault.Spec.Ingress.Spec.DefaultBackend = &netv1.IngressBackend{
 				Service: &netv1.IngressServiceBackend{
-					Name: vault.Name,
+					Name: serviceName,
 					Port: netv1.ServiceBackendPort{
-						Number: 8200,
+						Number: vault.Spec.GetAPIPort(),
 					},
 				},
 			}
@@ -1069,6 +2257,24 @@ func (vault *Vault) GetIngress() *Ingress {
 	return nil
 }
 
+// GetGatewayHostnames returns the hostnames of the Gateway API route
+func (vault *Vault) GetGatewayHostnames() []string {
+	if vault.Spec.Gateway == nil {
+		return nil
+	}
+	if len(vault.Spec.Gateway.Hostnames) > 0 {
+		return vault.Spec.Gateway.Hostnames
+	}
+
+	var hostnames []string
+	for _, host := range vault.Spec.TLSAdditionalHosts {
+		if host != "" && net.ParseIP(host) == nil {
+			hostnames = append(hostnames, host)
+		}
+	}
+	return hostnames
+}
+
 // LabelsForVault returns the labels for selecting the resources
 // belonging to the given vault CR name.
 func (vault *Vault) LabelsForVault() map[string]string {
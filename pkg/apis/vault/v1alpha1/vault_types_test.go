@@ -18,6 +18,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/utils/ptr"
 )
 
 func TestGetVersion(t *testing.T) {
@@ -83,3 +86,117 @@ func TestGetConfigPath(t *testing.T) {
 		require.Equal(t, "/openbao/config", path)
 	})
 }
+
+func TestAllowsConfigResourcesFrom(t *testing.T) {
+	vault := &Vault{}
+	vault.Namespace = "vault"
+
+	require.True(t, vault.AllowsConfigResourcesFrom("vault"))
+	require.False(t, vault.AllowsConfigResourcesFrom("team-a"))
+
+	vault.Spec.ConfigResourceNamespaces = []string{"team-a"}
+	require.True(t, vault.AllowsConfigResourcesFrom("team-a"))
+	require.False(t, vault.AllowsConfigResourcesFrom("team-b"))
+
+	vault.Spec.ConfigResourceNamespaces = []string{"*"}
+	require.True(t, vault.AllowsConfigResourcesFrom("team-b"))
+}
+
+func TestAllowsTransitUnsealFrom(t *testing.T) {
+	vault := &Vault{}
+	vault.Namespace = "vault"
+
+	require.True(t, vault.AllowsTransitUnsealFrom("vault"))
+	require.False(t, vault.AllowsTransitUnsealFrom("team-a"))
+
+	vault.Spec.TransitUnsealNamespaces = []string{"team-a"}
+	require.True(t, vault.AllowsTransitUnsealFrom("team-a"))
+	require.False(t, vault.AllowsTransitUnsealFrom("team-b"))
+
+	vault.Spec.TransitUnsealNamespaces = []string{"*"}
+	require.True(t, vault.AllowsTransitUnsealFrom("team-b"))
+}
+
+func TestRemoveCondition(t *testing.T) {
+	status := &VaultStatus{}
+	status.SetCondition(v1.ComponentCondition{Type: ConfigurationAppliedCondition, Status: v1.ConditionTrue})
+	status.SetCondition(v1.ComponentCondition{Type: v1.ComponentHealthy, Status: v1.ConditionTrue})
+
+	require.True(t, status.RemoveCondition(ConfigurationAppliedCondition))
+	require.Nil(t, status.GetCondition(ConfigurationAppliedCondition))
+	require.NotNil(t, status.GetCondition(v1.ComponentHealthy))
+	require.False(t, status.RemoveCondition(ConfigurationAppliedCondition))
+}
+
+func TestGetAPIAndClusterPort(t *testing.T) {
+	spec := VaultSpec{}
+	require.Equal(t, int32(8200), spec.GetAPIPort())
+	require.Equal(t, int32(8201), spec.GetClusterPort())
+
+	spec.Config = extv1beta1.JSON{Raw: []byte(`{"listener": {"tcp": {"address": "[::]:9200"}}}`)}
+	require.Equal(t, int32(9200), spec.GetAPIPort())
+	require.Equal(t, int32(9201), spec.GetClusterPort())
+
+	spec.ServicePorts = map[string]int32{"api-port": 443, "cluster-port": 8443}
+	require.Equal(t, int32(443), spec.GetAPIPort())
+	require.Equal(t, int32(8443), spec.GetClusterPort())
+}
+
+func TestSealForMigration(t *testing.T) {
+	vault := &Vault{}
+	vault.Spec.Config = extv1beta1.JSON{Raw: []byte(`{"storage": {"raft": {}}}`)}
+	vault.Spec.UnsealConfig.AWS = &AWSUnsealConfig{KMSKeyID: "alias/vault", KMSRegion: "eu-west-1"}
+
+	// The seal is only rendered in seal migration mode
+	require.Equal(t, SealTypeShamir, vault.Spec.GetSealType())
+	require.Nil(t, vault.Spec.GetRenderedSeal())
+	require.False(t, vault.Spec.IsAutoUnseal())
+
+	vault.Spec.UnsealConfig.Options.SealMigration = true
+	require.Equal(t, "awskms", vault.Spec.GetSealType())
+	require.True(t, vault.Spec.IsAutoUnseal())
+
+	configJSON, err := vault.ConfigJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"storage": {"raft": {}}, "seal": {"awskms": {"kms_key_id": "alias/vault", "region": "eu-west-1"}}}`, string(configJSON))
+
+	// The previous seal is disabled while migrating away from it
+	vault.Status.Seal = &SealStatus{Backend: "google", Type: "gcpckms", Config: map[string]string{"project": "vault"}}
+	vault.Status.SealMigration = &SealMigrationStatus{Phase: SealMigrationRestarting}
+	configJSON, err = vault.ConfigJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"storage": {"raft": {}}, "seal": {
+		"awskms": {"kms_key_id": "alias/vault", "region": "eu-west-1"},
+		"gcpckms": {"project": "vault", "disabled": "true"}
+	}}`, string(configJSON))
+
+	// A seal stanza in the config takes precedence
+	vault.Status.SealMigration.Phase = SealMigrationCompleted
+	vault.Spec.Config = extv1beta1.JSON{Raw: []byte(`{"seal": {"transit": {"disabled": "true"}, "gcpckms": {}}}`)}
+	require.Equal(t, "gcpckms", vault.Spec.GetSealType())
+	require.Nil(t, vault.Spec.GetRenderedSeal())
+}
+
+func TestRecoveryKeyOptions(t *testing.T) {
+	vault := &Vault{}
+	vault.Name = "vault"
+	vault.Namespace = "vault"
+	vault.Spec.UnsealConfig.Options.SecretShares = ptr.To[uint](5)
+	vault.Spec.UnsealConfig.Options.RecoveryShares = ptr.To[uint](7)
+	vault.Spec.UnsealConfig.Options.RecoveryThreshold = ptr.To[uint](4)
+
+	// Recovery keys are only generated by an auto-unsealed Vault
+	require.Equal(t, []string{"--secret-shares", "5"}, vault.Spec.UnsealConfig.ToArgs(vault)[:2])
+
+	vault.Spec.Config = extv1beta1.JSON{Raw: []byte(`{"seal": {"awskms": {}}}`)}
+	require.Equal(t, []string{"--secret-shares", "7", "--secret-threshold", "4"}, vault.Spec.UnsealConfig.ToArgs(vault)[:4])
+	require.Equal(t, 7, vault.Spec.UnsealConfig.Options.GetRecoveryShares())
+	require.Equal(t, 3, vault.Spec.UnsealConfig.Options.GetSecretThreshold())
+
+	namespace, name := vault.Spec.UnsealConfig.RecoveryKeysSecret(vault)
+	require.Equal(t, "vault/vault-unseal-keys", namespace+"/"+name)
+
+	vault.Spec.UnsealConfig.RecoveryKeys = &RecoveryKeysConfig{SecretNamespace: "audit"}
+	namespace, name = vault.Spec.UnsealConfig.RecoveryKeysSecret(vault)
+	require.Equal(t, "audit/vault-recovery-keys", namespace+"/"+name)
+}
@@ -0,0 +1,319 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// VaultReference points to the Vault instance a configuration resource applies to
+type VaultReference struct {
+	// Name of the Vault resource
+	Name string `json:"name"`
+
+	// Namespace of the Vault resource, the Vault has to allow it in ConfigResourceNamespaces
+	// if it differs from the namespace of the configuration resource.
+	// default: the namespace of the configuration resource
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// VaultConfigStatus defines the observed state of the Vault configuration resources
+type VaultConfigStatus struct {
+	// ObservedGeneration is the generation last applied to Vault
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Created is the name of the policy, or the path of the mount, this resource created in Vault,
+	// only that is changed by the resource and removed when it is deleted
+	Created    string                  `json:"created,omitempty"`
+	Conditions []v1.ComponentCondition `json:"conditions,omitempty"`
+}
+
+// GetCondition returns the condition with the given type, or nil if it is not present
+func (status *VaultConfigStatus) GetCondition(conditionType v1.ComponentConditionType) *v1.ComponentCondition {
+	return getCondition(status.Conditions, conditionType)
+}
+
+// SetCondition adds the condition or replaces the one with the same type, and returns true if the status changed
+func (status *VaultConfigStatus) SetCondition(condition v1.ComponentCondition) bool {
+	return setCondition(&status.Conditions, condition)
+}
+
+func vaultReferenceFor(meta metav1.ObjectMeta, ref VaultReference) types.NamespacedName {
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = meta.Namespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: ref.Name}
+}
+
+// VaultPolicySpec defines the desired state of VaultPolicy
+type VaultPolicySpec struct {
+	VaultRef VaultReference `json:"vaultRef"`
+
+	// Name of the policy in Vault, policies from other namespaces than the one of the Vault
+	// are named <namespace>.<name>.
+	// default: the name of the VaultPolicy resource
+	Name string `json:"name,omitempty"`
+
+	// Rules of the policy in HCL or JSON format
+	Rules string `json:"rules"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// VaultPolicy is the Schema for the vaultpolicies API
+type VaultPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VaultPolicySpec   `json:"spec,omitempty"`
+	Status VaultConfigStatus `json:"status,omitempty"`
+}
+
+// GetVaultReference returns the namespaced name of the referenced Vault
+func (policy *VaultPolicy) GetVaultReference() types.NamespacedName {
+	return vaultReferenceFor(policy.ObjectMeta, policy.Spec.VaultRef)
+}
+
+// GetConfigStatus returns the status of the VaultPolicy
+func (policy *VaultPolicy) GetConfigStatus() *VaultConfigStatus {
+	return &policy.Status
+}
+
+// PolicyName returns the name of the policy in Vault
+func (policy *VaultPolicy) PolicyName() string {
+	if policy.Spec.Name != "" {
+		return policy.Spec.Name
+	}
+	return policy.Name
+}
+
+// +kubebuilder:object:root=true
+
+// VaultPolicyList contains a list of VaultPolicy
+type VaultPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []VaultPolicy `json:"items"`
+}
+
+// VaultAuthBackendSpec defines the desired state of VaultAuthBackend
+type VaultAuthBackendSpec struct {
+	VaultRef VaultReference `json:"vaultRef"`
+
+	// Type of the auth method, for example kubernetes, jwt or approle
+	Type string `json:"type"`
+
+	// Path the auth method is enabled at, auth methods from other namespaces than the one of the Vault
+	// are enabled under <namespace>/.
+	// default: Type
+	Path string `json:"path,omitempty"`
+
+	// Description of the auth method
+	// default:
+	Description string `json:"description,omitempty"`
+
+	// Options is the mount configuration of the auth method, for example default_lease_ttl
+	// default:
+	Options extv1beta1.JSON `json:"options,omitempty"`
+
+	// Config is written to auth/<path>/config
+	// default:
+	Config extv1beta1.JSON `json:"config,omitempty"`
+
+	// Roles are written to auth/<path>/role/<name>, each role needs a name field
+	// default:
+	Roles []extv1beta1.JSON `json:"roles,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// VaultAuthBackend is the Schema for the vaultauthbackends API
+type VaultAuthBackend struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VaultAuthBackendSpec `json:"spec,omitempty"`
+	Status VaultConfigStatus    `json:"status,omitempty"`
+}
+
+// GetVaultReference returns the namespaced name of the referenced Vault
+func (auth *VaultAuthBackend) GetVaultReference() types.NamespacedName {
+	return vaultReferenceFor(auth.ObjectMeta, auth.Spec.VaultRef)
+}
+
+// GetConfigStatus returns the status of the VaultAuthBackend
+func (auth *VaultAuthBackend) GetConfigStatus() *VaultConfigStatus {
+	return &auth.Status
+}
+
+// +kubebuilder:object:root=true
+
+// VaultAuthBackendList contains a list of VaultAuthBackend
+type VaultAuthBackendList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []VaultAuthBackend `json:"items"`
+}
+
+// VaultSecretEngineSpec defines the desired state of VaultSecretEngine
+type VaultSecretEngineSpec struct {
+	VaultRef VaultReference `json:"vaultRef"`
+
+	// Type of the secret engine, for example kv, database or pki
+	Type string `json:"type"`
+
+	// Path the secret engine is mounted at, secret engines from other namespaces than the one of the Vault
+	// are mounted under <namespace>/.
+	// default: Type
+	Path string `json:"path,omitempty"`
+
+	// Description of the secret engine
+	// default:
+	Description string `json:"description,omitempty"`
+
+	// Local marks the mount as local to the cluster, it is not replicated
+	// default: false
+	Local bool `json:"local,omitempty"`
+
+	// SealWrap enables seal wrapping for the mount
+	// default: false
+	SealWrap bool `json:"sealWrap,omitempty"`
+
+	// Options of the secret engine, for example version: "2" for kv
+	// default:
+	Options map[string]string `json:"options,omitempty"`
+
+	// Config is the mount configuration of the secret engine, for example default_lease_ttl
+	// default:
+	Config extv1beta1.JSON `json:"config,omitempty"`
+
+	// Configuration maps paths under the mount to a list of objects, every object is written
+	// to <path>/<key>/<name> (or <path>/<key> if it has no name field)
+	// default:
+	Configuration extv1beta1.JSON `json:"configuration,omitempty"`
+
+	// RetainOnDelete keeps the secret engine and its data mounted when the resource gets deleted
+	// default: false
+	RetainOnDelete bool `json:"retainOnDelete,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// VaultSecretEngine is the Schema for the vaultsecretengines API
+type VaultSecretEngine struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VaultSecretEngineSpec `json:"spec,omitempty"`
+	Status VaultConfigStatus     `json:"status,omitempty"`
+}
+
+// GetVaultReference returns the namespaced name of the referenced Vault
+func (engine *VaultSecretEngine) GetVaultReference() types.NamespacedName {
+	return vaultReferenceFor(engine.ObjectMeta, engine.Spec.VaultRef)
+}
+
+// GetConfigStatus returns the status of the VaultSecretEngine
+func (engine *VaultSecretEngine) GetConfigStatus() *VaultConfigStatus {
+	return &engine.Status
+}
+
+// +kubebuilder:object:root=true
+
+// VaultSecretEngineList contains a list of VaultSecretEngine
+type VaultSecretEngineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []VaultSecretEngine `json:"items"`
+}
+
+// VaultAuditDeviceSpec defines the desired state of VaultAuditDevice
+type VaultAuditDeviceSpec struct {
+	VaultRef VaultReference `json:"vaultRef"`
+
+	// Type of the audit device, for example file, syslog or socket
+	Type string `json:"type"`
+
+	// Path the audit device is enabled at, audit devices from other namespaces than the one of the Vault
+	// are enabled under <namespace>/.
+	// default: Type
+	Path string `json:"path,omitempty"`
+
+	// Description of the audit device
+	// default:
+	Description string `json:"description,omitempty"`
+
+	// Local marks the audit device as local to the cluster, it is not replicated
+	// default: false
+	Local bool `json:"local,omitempty"`
+
+	// Options of the audit device, for example file_path for the file type
+	// default:
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// VaultAuditDevice is the Schema for the vaultauditdevices API
+type VaultAuditDevice struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VaultAuditDeviceSpec `json:"spec,omitempty"`
+	Status VaultConfigStatus    `json:"status,omitempty"`
+}
+
+// GetVaultReference returns the namespaced name of the referenced Vault
+func (audit *VaultAuditDevice) GetVaultReference() types.NamespacedName {
+	return vaultReferenceFor(audit.ObjectMeta, audit.Spec.VaultRef)
+}
+
+// GetConfigStatus returns the status of the VaultAuditDevice
+func (audit *VaultAuditDevice) GetConfigStatus() *VaultConfigStatus {
+	return &audit.Status
+}
+
+// +kubebuilder:object:root=true
+
+// VaultAuditDeviceList contains a list of VaultAuditDevice
+type VaultAuditDeviceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []VaultAuditDevice `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&VaultPolicy{}, &VaultPolicyList{},
+		&VaultAuthBackend{}, &VaultAuthBackendList{},
+		&VaultSecretEngine{}, &VaultSecretEngineList{},
+		&VaultAuditDevice{}, &VaultAuditDeviceList{},
+	)
+}
@@ -20,7 +20,11 @@ package v1alpha1
 
 import (
 	"k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -83,6 +87,68 @@ func (in *CredentialsConfig) DeepCopy() *CredentialsConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Custodian) DeepCopyInto(out *Custodian) {
+	*out = *in
+	if in.PGPKeySecretRef != nil {
+		in, out := &in.PGPKeySecretRef, &out.PGPKeySecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PGPKeyConfigMapRef != nil {
+		in, out := &in.PGPKeyConfigMapRef, &out.PGPKeyConfigMapRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Custodian.
+func (in *Custodian) DeepCopy() *Custodian {
+	if in == nil {
+		return nil
+	}
+	out := new(Custodian)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustodianUnsealConfig) DeepCopyInto(out *CustodianUnsealConfig) {
+	*out = *in
+	if in.Custodians != nil {
+		in, out := &in.Custodians, &out.Custodians
+		*out = make([]Custodian, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustodianUnsealConfig.
+func (in *CustodianUnsealConfig) DeepCopy() *CustodianUnsealConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CustodianUnsealConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftDetection) DeepCopyInto(out *DriftDetection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftDetection.
+func (in *DriftDetection) DeepCopy() *DriftDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmbeddedObjectMetadata) DeepCopyInto(out *EmbeddedObjectMetadata) {
 	*out = *in
@@ -305,218 +371,1049 @@ func (in *EmbeddedPodSpec) DeepCopy() *EmbeddedPodSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Gateway) DeepCopyInto(out *Gateway) {
+	*out = *in
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]GatewayParentReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StandbyHostnames != nil {
+		in, out := &in.StandbyHostnames, &out.StandbyHostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Gateway.
+func (in *Gateway) DeepCopy() *Gateway {
+	if in == nil {
+		return nil
+	}
+	out := new(Gateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayParentReference) DeepCopyInto(out *GatewayParentReference) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParentReference.
+func (in *GatewayParentReference) DeepCopy() *GatewayParentReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayParentReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GoogleUnsealConfig) DeepCopyInto(out *GoogleUnsealConfig) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoogleUnsealConfig.
-func (in *GoogleUnsealConfig) DeepCopy() *GoogleUnsealConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoogleUnsealConfig.
+func (in *GoogleUnsealConfig) DeepCopy() *GoogleUnsealConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GoogleUnsealConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HSMUnsealConfig) DeepCopyInto(out *HSMUnsealConfig) {
+	*out = *in
+	if in.PinSecretRef != nil {
+		in, out := &in.PinSecretRef, &out.PinSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HSMUnsealConfig.
+func (in *HSMUnsealConfig) DeepCopy() *HSMUnsealConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HSMUnsealConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Ingress) DeepCopyInto(out *Ingress) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.StandbyHosts != nil {
+		in, out := &in.StandbyHosts, &out.StandbyHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ingress.
+func (in *Ingress) DeepCopy() *Ingress {
+	if in == nil {
+		return nil
+	}
+	out := new(Ingress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyRotation) DeepCopyInto(out *KeyRotation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyRotation.
+func (in *KeyRotation) DeepCopy() *KeyRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyRotationStatus) DeepCopyInto(out *KeyRotationStatus) {
+	*out = *in
+	if in.LastRekeyTime != nil {
+		in, out := &in.LastRekeyTime, &out.LastRekeyTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRootTokenTime != nil {
+		in, out := &in.LastRootTokenTime, &out.LastRootTokenTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyRotationStatus.
+func (in *KeyRotationStatus) DeepCopy() *KeyRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesAuthConfig) DeepCopyInto(out *KubernetesAuthConfig) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]KubernetesAuthRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesAuthConfig.
+func (in *KubernetesAuthConfig) DeepCopy() *KubernetesAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesAuthRole) DeepCopyInto(out *KubernetesAuthRole) {
+	*out = *in
+	if in.BoundServiceAccountNames != nil {
+		in, out := &in.BoundServiceAccountNames, &out.BoundServiceAccountNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BoundServiceAccountNamespaces != nil {
+		in, out := &in.BoundServiceAccountNamespaces, &out.BoundServiceAccountNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesAuthRole.
+func (in *KubernetesAuthRole) DeepCopy() *KubernetesAuthRole {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesAuthRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesUnsealConfig) DeepCopyInto(out *KubernetesUnsealConfig) {
+	*out = *in
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(UnsealKeyEncryption)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesUnsealConfig.
+func (in *KubernetesUnsealConfig) DeepCopy() *KubernetesUnsealConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesUnsealConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicy) DeepCopyInto(out *NetworkPolicy) {
+	*out = *in
+	if in.APIPeers != nil {
+		in, out := &in.APIPeers, &out.APIPeers
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicy.
+func (in *NetworkPolicy) DeepCopy() *NetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCIUnsealConfig) DeepCopyInto(out *OCIUnsealConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIUnsealConfig.
+func (in *OCIUnsealConfig) DeepCopy() *OCIUnsealConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OCIUnsealConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorIdentityConfig) DeepCopyInto(out *OperatorIdentityConfig) {
+	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorIdentityConfig.
+func (in *OperatorIdentityConfig) DeepCopy() *OperatorIdentityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorIdentityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudget) DeepCopyInto(out *PodDisruptionBudget) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudget.
+func (in *PodDisruptionBudget) DeepCopy() *PodDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBAC) DeepCopyInto(out *RBAC) {
+	*out = *in
+	if in.ServiceAccountAnnotations != nil {
+		in, out := &in.ServiceAccountAnnotations, &out.ServiceAccountAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBAC.
+func (in *RBAC) DeepCopy() *RBAC {
+	if in == nil {
+		return nil
+	}
+	out := new(RBAC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecoveryKeysConfig) DeepCopyInto(out *RecoveryKeysConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecoveryKeysConfig.
+func (in *RecoveryKeysConfig) DeepCopy() *RecoveryKeysConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RecoveryKeysConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecoveryKeysStatus) DeepCopyInto(out *RecoveryKeysStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecoveryKeysStatus.
+func (in *RecoveryKeysStatus) DeepCopy() *RecoveryKeysStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RecoveryKeysStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationPolicy) DeepCopyInto(out *RemediationPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationPolicy.
+func (in *RemediationPolicy) DeepCopy() *RemediationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationRecord) DeepCopyInto(out *RemediationRecord) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationRecord.
+func (in *RemediationRecord) DeepCopy() *RemediationRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationStatus) DeepCopyInto(out *RemediationStatus) {
+	*out = *in
+	if in.UnhealthyPods != nil {
+		in, out := &in.UnhealthyPods, &out.UnhealthyPods
+		*out = make([]UnhealthyPod, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]RemediationRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RemovedClaims != nil {
+		in, out := &in.RemovedClaims, &out.RemovedClaims
+		*out = make([]RemovedClaim, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationStatus.
+func (in *RemediationStatus) DeepCopy() *RemediationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemovedClaim) DeepCopyInto(out *RemovedClaim) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemovedClaim.
+func (in *RemovedClaim) DeepCopy() *RemovedClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(RemovedClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Resources) DeepCopyInto(out *Resources) {
+	*out = *in
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BankVaults != nil {
+		in, out := &in.BankVaults, &out.BankVaults
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HSMDaemon != nil {
+		in, out := &in.HSMDaemon, &out.HSMDaemon
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrometheusExporter != nil {
+		in, out := &in.PrometheusExporter, &out.PrometheusExporter
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FluentD != nil {
+		in, out := &in.FluentD, &out.FluentD
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Resources.
+func (in *Resources) DeepCopy() *Resources {
+	if in == nil {
+		return nil
+	}
+	out := new(Resources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SealMigrationStatus) DeepCopyInto(out *SealMigrationStatus) {
+	*out = *in
+	in.Target.DeepCopyInto(&out.Target)
+	if in.MigratedPods != nil {
+		in, out := &in.MigratedPods, &out.MigratedPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SealMigrationStatus.
+func (in *SealMigrationStatus) DeepCopy() *SealMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SealMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SealStatus) DeepCopyInto(out *SealStatus) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SealStatus.
+func (in *SealStatus) DeepCopy() *SealStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SealStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnhealthyPod) DeepCopyInto(out *UnhealthyPod) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnhealthyPod.
+func (in *UnhealthyPod) DeepCopy() *UnhealthyPod {
+	if in == nil {
+		return nil
+	}
+	out := new(UnhealthyPod)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnsealConfig) DeepCopyInto(out *UnsealConfig) {
+	*out = *in
+	in.Options.DeepCopyInto(&out.Options)
+	in.Kubernetes.DeepCopyInto(&out.Kubernetes)
+	if in.Google != nil {
+		in, out := &in.Google, &out.Google
+		*out = new(GoogleUnsealConfig)
+		**out = **in
+	}
+	if in.Alibaba != nil {
+		in, out := &in.Alibaba, &out.Alibaba
+		*out = new(AlibabaUnsealConfig)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureUnsealConfig)
+		**out = **in
+	}
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSUnsealConfig)
+		**out = **in
+	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(OCIUnsealConfig)
+		**out = **in
+	}
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultUnsealConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HSM != nil {
+		in, out := &in.HSM, &out.HSM
+		*out = new(HSMUnsealConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Custodians != nil {
+		in, out := &in.Custodians, &out.Custodians
+		*out = new(CustodianUnsealConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RecoveryKeys != nil {
+		in, out := &in.RecoveryKeys, &out.RecoveryKeys
+		*out = new(RecoveryKeysConfig)
+		**out = **in
+	}
+	if in.VaultRef != nil {
+		in, out := &in.VaultRef, &out.VaultRef
+		*out = new(VaultRefUnsealConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnsealConfig.
+func (in *UnsealConfig) DeepCopy() *UnsealConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UnsealConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnsealKeyEncryption) DeepCopyInto(out *UnsealKeyEncryption) {
+	*out = *in
+	if in.KEKSecretRef != nil {
+		in, out := &in.KEKSecretRef, &out.KEKSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnsealKeyEncryption.
+func (in *UnsealKeyEncryption) DeepCopy() *UnsealKeyEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(UnsealKeyEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnsealOptions) DeepCopyInto(out *UnsealOptions) {
+	*out = *in
+	if in.PreFlightChecks != nil {
+		in, out := &in.PreFlightChecks, &out.PreFlightChecks
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StoreRootToken != nil {
+		in, out := &in.StoreRootToken, &out.StoreRootToken
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SecretThreshold != nil {
+		in, out := &in.SecretThreshold, &out.SecretThreshold
+		*out = new(uint)
+		**out = **in
+	}
+	if in.SecretShares != nil {
+		in, out := &in.SecretShares, &out.SecretShares
+		*out = new(uint)
+		**out = **in
+	}
+	if in.RecoveryThreshold != nil {
+		in, out := &in.RecoveryThreshold, &out.RecoveryThreshold
+		*out = new(uint)
+		**out = **in
+	}
+	if in.RecoveryShares != nil {
+		in, out := &in.RecoveryShares, &out.RecoveryShares
+		*out = new(uint)
+		**out = **in
+	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(KeyRotation)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnsealOptions.
+func (in *UnsealOptions) DeepCopy() *UnsealOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(UnsealOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Vault) DeepCopyInto(out *Vault) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Vault.
+func (in *Vault) DeepCopy() *Vault {
+	if in == nil {
+		return nil
+	}
+	out := new(Vault)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Vault) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuditDevice) DeepCopyInto(out *VaultAuditDevice) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuditDevice.
+func (in *VaultAuditDevice) DeepCopy() *VaultAuditDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuditDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultAuditDevice) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuditDeviceList) DeepCopyInto(out *VaultAuditDeviceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultAuditDevice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuditDeviceList.
+func (in *VaultAuditDeviceList) DeepCopy() *VaultAuditDeviceList {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuditDeviceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultAuditDeviceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuditDeviceSpec) DeepCopyInto(out *VaultAuditDeviceSpec) {
+	*out = *in
+	out.VaultRef = in.VaultRef
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuditDeviceSpec.
+func (in *VaultAuditDeviceSpec) DeepCopy() *VaultAuditDeviceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuditDeviceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuthBackend) DeepCopyInto(out *VaultAuthBackend) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuthBackend.
+func (in *VaultAuthBackend) DeepCopy() *VaultAuthBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuthBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultAuthBackend) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuthBackendList) DeepCopyInto(out *VaultAuthBackendList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultAuthBackend, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuthBackendList.
+func (in *VaultAuthBackendList) DeepCopy() *VaultAuthBackendList {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuthBackendList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultAuthBackendList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuthBackendSpec) DeepCopyInto(out *VaultAuthBackendSpec) {
+	*out = *in
+	out.VaultRef = in.VaultRef
+	in.Options.DeepCopyInto(&out.Options)
+	in.Config.DeepCopyInto(&out.Config)
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]apiextensionsv1beta1.JSON, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuthBackendSpec.
+func (in *VaultAuthBackendSpec) DeepCopy() *VaultAuthBackendSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GoogleUnsealConfig)
+	out := new(VaultAuthBackendSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HSMUnsealConfig) DeepCopyInto(out *HSMUnsealConfig) {
+func (in *VaultConfigStatus) DeepCopyInto(out *VaultConfigStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.ComponentCondition, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HSMUnsealConfig.
-func (in *HSMUnsealConfig) DeepCopy() *HSMUnsealConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultConfigStatus.
+func (in *VaultConfigStatus) DeepCopy() *VaultConfigStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(HSMUnsealConfig)
+	out := new(VaultConfigStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Ingress) DeepCopyInto(out *Ingress) {
+func (in *VaultList) DeepCopyInto(out *VaultList) {
 	*out = *in
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Vault, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ingress.
-func (in *Ingress) DeepCopy() *Ingress {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultList.
+func (in *VaultList) DeepCopy() *VaultList {
 	if in == nil {
 		return nil
 	}
-	out := new(Ingress)
+	out := new(VaultList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesUnsealConfig) DeepCopyInto(out *KubernetesUnsealConfig) {
+func (in *VaultPolicy) DeepCopyInto(out *VaultPolicy) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesUnsealConfig.
-func (in *KubernetesUnsealConfig) DeepCopy() *KubernetesUnsealConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultPolicy.
+func (in *VaultPolicy) DeepCopy() *VaultPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesUnsealConfig)
+	out := new(VaultPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OCIUnsealConfig) DeepCopyInto(out *OCIUnsealConfig) {
+func (in *VaultPolicyList) DeepCopyInto(out *VaultPolicyList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIUnsealConfig.
-func (in *OCIUnsealConfig) DeepCopy() *OCIUnsealConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultPolicyList.
+func (in *VaultPolicyList) DeepCopy() *VaultPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(OCIUnsealConfig)
+	out := new(VaultPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Resources) DeepCopyInto(out *Resources) {
+func (in *VaultPolicySpec) DeepCopyInto(out *VaultPolicySpec) {
 	*out = *in
-	if in.Vault != nil {
-		in, out := &in.Vault, &out.Vault
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.BankVaults != nil {
-		in, out := &in.BankVaults, &out.BankVaults
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.HSMDaemon != nil {
-		in, out := &in.HSMDaemon, &out.HSMDaemon
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PrometheusExporter != nil {
-		in, out := &in.PrometheusExporter, &out.PrometheusExporter
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.FluentD != nil {
-		in, out := &in.FluentD, &out.FluentD
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
+	out.VaultRef = in.VaultRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Resources.
-func (in *Resources) DeepCopy() *Resources {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultPolicySpec.
+func (in *VaultPolicySpec) DeepCopy() *VaultPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Resources)
+	out := new(VaultPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *UnsealConfig) DeepCopyInto(out *UnsealConfig) {
+func (in *VaultRefUnsealConfig) DeepCopyInto(out *VaultRefUnsealConfig) {
 	*out = *in
-	in.Options.DeepCopyInto(&out.Options)
-	out.Kubernetes = in.Kubernetes
-	if in.Google != nil {
-		in, out := &in.Google, &out.Google
-		*out = new(GoogleUnsealConfig)
-		**out = **in
-	}
-	if in.Alibaba != nil {
-		in, out := &in.Alibaba, &out.Alibaba
-		*out = new(AlibabaUnsealConfig)
-		**out = **in
-	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(AzureUnsealConfig)
-		**out = **in
-	}
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(AWSUnsealConfig)
-		**out = **in
-	}
-	if in.OCI != nil {
-		in, out := &in.OCI, &out.OCI
-		*out = new(OCIUnsealConfig)
-		**out = **in
-	}
-	if in.Vault != nil {
-		in, out := &in.Vault, &out.Vault
-		*out = new(VaultUnsealConfig)
-		**out = **in
-	}
-	if in.HSM != nil {
-		in, out := &in.HSM, &out.HSM
-		*out = new(HSMUnsealConfig)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnsealConfig.
-func (in *UnsealConfig) DeepCopy() *UnsealConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultRefUnsealConfig.
+func (in *VaultRefUnsealConfig) DeepCopy() *VaultRefUnsealConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(UnsealConfig)
+	out := new(VaultRefUnsealConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *UnsealOptions) DeepCopyInto(out *UnsealOptions) {
+func (in *VaultReference) DeepCopyInto(out *VaultReference) {
 	*out = *in
-	if in.PreFlightChecks != nil {
-		in, out := &in.PreFlightChecks, &out.PreFlightChecks
-		*out = new(bool)
-		**out = **in
-	}
-	if in.StoreRootToken != nil {
-		in, out := &in.StoreRootToken, &out.StoreRootToken
-		*out = new(bool)
-		**out = **in
-	}
-	if in.SecretThreshold != nil {
-		in, out := &in.SecretThreshold, &out.SecretThreshold
-		*out = new(uint)
-		**out = **in
-	}
-	if in.SecretShares != nil {
-		in, out := &in.SecretShares, &out.SecretShares
-		*out = new(uint)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnsealOptions.
-func (in *UnsealOptions) DeepCopy() *UnsealOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultReference.
+func (in *VaultReference) DeepCopy() *VaultReference {
 	if in == nil {
 		return nil
 	}
-	out := new(UnsealOptions)
+	out := new(VaultReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Vault) DeepCopyInto(out *Vault) {
+func (in *VaultSecretEngine) DeepCopyInto(out *VaultSecretEngine) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -524,18 +1421,18 @@ func (in *Vault) DeepCopyInto(out *Vault) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Vault.
-func (in *Vault) DeepCopy() *Vault {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretEngine.
+func (in *VaultSecretEngine) DeepCopy() *VaultSecretEngine {
 	if in == nil {
 		return nil
 	}
-	out := new(Vault)
+	out := new(VaultSecretEngine)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Vault) DeepCopyObject() runtime.Object {
+func (in *VaultSecretEngine) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -543,37 +1440,62 @@ func (in *Vault) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VaultList) DeepCopyInto(out *VaultList) {
+func (in *VaultSecretEngineList) DeepCopyInto(out *VaultSecretEngineList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Vault, len(*in))
+		*out = make([]VaultSecretEngine, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultList.
-func (in *VaultList) DeepCopy() *VaultList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretEngineList.
+func (in *VaultSecretEngineList) DeepCopy() *VaultSecretEngineList {
 	if in == nil {
 		return nil
 	}
-	out := new(VaultList)
+	out := new(VaultSecretEngineList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VaultList) DeepCopyObject() runtime.Object {
+func (in *VaultSecretEngineList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultSecretEngineSpec) DeepCopyInto(out *VaultSecretEngineSpec) {
+	*out = *in
+	out.VaultRef = in.VaultRef
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Config.DeepCopyInto(&out.Config)
+	in.Configuration.DeepCopyInto(&out.Configuration)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretEngineSpec.
+func (in *VaultSecretEngineSpec) DeepCopy() *VaultSecretEngineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultSecretEngineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VaultSpec) DeepCopyInto(out *VaultSpec) {
 	*out = *in
@@ -610,6 +1532,20 @@ func (in *VaultSpec) DeepCopyInto(out *VaultSpec) {
 			}
 		}
 	}
+	if in.WatchedSecretsSelectors != nil {
+		in, out := &in.WatchedSecretsSelectors, &out.WatchedSecretsSelectors
+		*out = make([]metav1.LabelSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WatchedSecretsAnnotationsSelectors != nil {
+		in, out := &in.WatchedSecretsAnnotationsSelectors, &out.WatchedSecretsAnnotationsSelectors
+		*out = make([]metav1.LabelSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Annotations != nil {
 		in, out := &in.Annotations, &out.Annotations
 		*out = make(map[string]string, len(*in))
@@ -658,6 +1594,36 @@ func (in *VaultSpec) DeepCopyInto(out *VaultSpec) {
 	}
 	in.Config.DeepCopyInto(&out.Config)
 	in.ExternalConfig.DeepCopyInto(&out.ExternalConfig)
+	if in.ConfigResourceNamespaces != nil {
+		in, out := &in.ConfigResourceNamespaces, &out.ConfigResourceNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TransitUnsealNamespaces != nil {
+		in, out := &in.TransitUnsealNamespaces, &out.TransitUnsealNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DriftDetection != nil {
+		in, out := &in.DriftDetection, &out.DriftDetection
+		*out = new(DriftDetection)
+		**out = **in
+	}
+	if in.KubernetesAuth != nil {
+		in, out := &in.KubernetesAuth, &out.KubernetesAuth
+		*out = new(KubernetesAuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OperatorIdentity != nil {
+		in, out := &in.OperatorIdentity, &out.OperatorIdentity
+		*out = new(OperatorIdentityConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(RemediationPolicy)
+		**out = **in
+	}
 	in.UnsealConfig.DeepCopyInto(&out.UnsealConfig)
 	out.CredentialsConfig = in.CredentialsConfig
 	if in.EnvsConfig != nil {
@@ -668,6 +1634,26 @@ func (in *VaultSpec) DeepCopyInto(out *VaultSpec) {
 		}
 	}
 	in.SecurityContext.DeepCopyInto(&out.SecurityContext)
+	if in.IPFamilyPolicy != nil {
+		in, out := &in.IPFamilyPolicy, &out.IPFamilyPolicy
+		*out = new(v1.IPFamilyPolicy)
+		**out = **in
+	}
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]v1.IPFamily, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ServicePorts != nil {
 		in, out := &in.ServicePorts, &out.ServicePorts
 		*out = make(map[string]int32, len(*in))
@@ -695,6 +1681,11 @@ func (in *VaultSpec) DeepCopyInto(out *VaultSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RBAC != nil {
+		in, out := &in.RBAC, &out.RBAC
+		*out = new(RBAC)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make([]v1.Volume, len(*in))
@@ -747,6 +1738,11 @@ func (in *VaultSpec) DeepCopyInto(out *VaultSpec) {
 		*out = new(Ingress)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(Gateway)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TLSAdditionalHosts != nil {
 		in, out := &in.TLSAdditionalHosts, &out.TLSAdditionalHosts
 		*out = make([]string, len(*in))
@@ -796,6 +1792,50 @@ func (in *VaultStatus) DeepCopyInto(out *VaultStatus) {
 		*out = make([]v1.ComponentCondition, len(*in))
 		copy(*out, *in)
 	}
+	if in.PodIPs != nil {
+		in, out := &in.PodIPs, &out.PodIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GatewayObjects != nil {
+		in, out := &in.GatewayObjects, &out.GatewayObjects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastDriftCheckTime != nil {
+		in, out := &in.LastDriftCheckTime, &out.LastDriftCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Seal != nil {
+		in, out := &in.Seal, &out.Seal
+		*out = new(SealStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SealMigration != nil {
+		in, out := &in.SealMigration, &out.SealMigration
+		*out = new(SealMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeyRotation != nil {
+		in, out := &in.KeyRotation, &out.KeyRotation
+		*out = new(KeyRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RecoveryKeys != nil {
+		in, out := &in.RecoveryKeys, &out.RecoveryKeys
+		*out = new(RecoveryKeysStatus)
+		**out = **in
+	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(RemediationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultStatus.
@@ -811,6 +1851,11 @@ func (in *VaultStatus) DeepCopy() *VaultStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VaultUnsealConfig) DeepCopyInto(out *VaultUnsealConfig) {
 	*out = *in
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultUnsealConfig.
@@ -26,6 +26,22 @@ type FakeVaultV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeVaultV1alpha1) VaultAuditDevices(namespace string) v1alpha1.VaultAuditDeviceInterface {
+	return &FakeVaultAuditDevices{c, namespace}
+}
+
+func (c *FakeVaultV1alpha1) VaultAuthBackends(namespace string) v1alpha1.VaultAuthBackendInterface {
+	return &FakeVaultAuthBackends{c, namespace}
+}
+
+func (c *FakeVaultV1alpha1) VaultPolicies(namespace string) v1alpha1.VaultPolicyInterface {
+	return &FakeVaultPolicies{c, namespace}
+}
+
+func (c *FakeVaultV1alpha1) VaultSecretEngines(namespace string) v1alpha1.VaultSecretEngineInterface {
+	return &FakeVaultSecretEngines{c, namespace}
+}
+
 func (c *FakeVaultV1alpha1) Vaults(namespace string) v1alpha1.VaultInterface {
 	return &FakeVaults{c, namespace}
 }
@@ -17,3 +17,11 @@
 package v1alpha1
 
 type VaultExpansion interface{}
+
+type VaultAuditDeviceExpansion interface{}
+
+type VaultAuthBackendExpansion interface{}
+
+type VaultPolicyExpansion interface{}
+
+type VaultSecretEngineExpansion interface{}
@@ -26,6 +26,10 @@ import (
 
 type VaultV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	VaultAuditDevicesGetter
+	VaultAuthBackendsGetter
+	VaultPoliciesGetter
+	VaultSecretEnginesGetter
 	VaultsGetter
 }
 
@@ -34,6 +38,22 @@ type VaultV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *VaultV1alpha1Client) VaultAuditDevices(namespace string) VaultAuditDeviceInterface {
+	return newVaultAuditDevices(c, namespace)
+}
+
+func (c *VaultV1alpha1Client) VaultAuthBackends(namespace string) VaultAuthBackendInterface {
+	return newVaultAuthBackends(c, namespace)
+}
+
+func (c *VaultV1alpha1Client) VaultPolicies(namespace string) VaultPolicyInterface {
+	return newVaultPolicies(c, namespace)
+}
+
+func (c *VaultV1alpha1Client) VaultSecretEngines(namespace string) VaultSecretEngineInterface {
+	return newVaultSecretEngines(c, namespace)
+}
+
 func (c *VaultV1alpha1Client) Vaults(namespace string) VaultInterface {
 	return newVaults(c, namespace)
 }
@@ -51,6 +51,14 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=vault, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("vaultauditdevices"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Vault().V1alpha1().VaultAuditDevices().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("vaultauthbackends"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Vault().V1alpha1().VaultAuthBackends().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("vaultpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Vault().V1alpha1().VaultPolicies().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("vaultsecretengines"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Vault().V1alpha1().VaultSecretEngines().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("vaults"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Vault().V1alpha1().Vaults().Informer()}, nil
 
@@ -22,6 +22,14 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// VaultAuditDevices returns a VaultAuditDeviceInformer.
+	VaultAuditDevices() VaultAuditDeviceInformer
+	// VaultAuthBackends returns a VaultAuthBackendInformer.
+	VaultAuthBackends() VaultAuthBackendInformer
+	// VaultPolicies returns a VaultPolicyInformer.
+	VaultPolicies() VaultPolicyInformer
+	// VaultSecretEngines returns a VaultSecretEngineInformer.
+	VaultSecretEngines() VaultSecretEngineInformer
 	// Vaults returns a VaultInformer.
 	Vaults() VaultInformer
 }
@@ -41,3 +49,23 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) Vaults() VaultInformer {
 	return &vaultInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// VaultAuditDevices returns a VaultAuditDeviceInformer.
+func (v *version) VaultAuditDevices() VaultAuditDeviceInformer {
+	return &vaultAuditDeviceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// VaultAuthBackends returns a VaultAuthBackendInformer.
+func (v *version) VaultAuthBackends() VaultAuthBackendInformer {
+	return &vaultAuthBackendInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// VaultPolicies returns a VaultPolicyInformer.
+func (v *version) VaultPolicies() VaultPolicyInformer {
+	return &vaultPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// VaultSecretEngines returns a VaultSecretEngineInformer.
+func (v *version) VaultSecretEngines() VaultSecretEngineInformer {
+	return &vaultSecretEngineInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -23,3 +23,35 @@ type VaultListerExpansion interface{}
 // VaultNamespaceListerExpansion allows custom methods to be added to
 // VaultNamespaceLister.
 type VaultNamespaceListerExpansion interface{}
+
+// VaultAuditDeviceListerExpansion allows custom methods to be added to
+// VaultAuditDeviceLister.
+type VaultAuditDeviceListerExpansion interface{}
+
+// VaultAuditDeviceNamespaceListerExpansion allows custom methods to be added to
+// VaultAuditDeviceNamespaceLister.
+type VaultAuditDeviceNamespaceListerExpansion interface{}
+
+// VaultAuthBackendListerExpansion allows custom methods to be added to
+// VaultAuthBackendLister.
+type VaultAuthBackendListerExpansion interface{}
+
+// VaultAuthBackendNamespaceListerExpansion allows custom methods to be added to
+// VaultAuthBackendNamespaceLister.
+type VaultAuthBackendNamespaceListerExpansion interface{}
+
+// VaultPolicyListerExpansion allows custom methods to be added to
+// VaultPolicyLister.
+type VaultPolicyListerExpansion interface{}
+
+// VaultPolicyNamespaceListerExpansion allows custom methods to be added to
+// VaultPolicyNamespaceLister.
+type VaultPolicyNamespaceListerExpansion interface{}
+
+// VaultSecretEngineListerExpansion allows custom methods to be added to
+// VaultSecretEngineLister.
+type VaultSecretEngineListerExpansion interface{}
+
+// VaultSecretEngineNamespaceListerExpansion allows custom methods to be added to
+// VaultSecretEngineNamespaceLister.
+type VaultSecretEngineNamespaceListerExpansion interface{}
@@ -0,0 +1,322 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// unsealWithCustodians initialises Vault with the PGP keys of the custodians, and unseals the Vault pods
+// with the key shares submitted by the custodians, it returns the resulting condition
+func (r *ReconcileVault) unsealWithCustodians(ctx context.Context, v *vaultv1alpha1.Vault) corev1.ComponentCondition {
+	condition := corev1.ComponentCondition{
+		Type:   vaultv1alpha1.UnsealedCondition,
+		Status: corev1.ConditionTrue,
+	}
+
+	err := func() error {
+		// The other pods join the first one
+		firstPod := v.Name + "-0"
+		vaultClient, err := r.httpClients.vaultClientForPod(ctx, r.client, v, firstPod)
+		if err != nil {
+			return err
+		}
+
+		initialized, err := vaultClient.Sys().InitStatusWithContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get init status of pod %s: %v", firstPod, err)
+		}
+		if !initialized {
+			if err := r.initWithCustodians(ctx, v, vaultClient); err != nil {
+				return err
+			}
+		}
+
+		shares, err := r.submittedKeyShares(ctx, v)
+		if err != nil {
+			return err
+		}
+
+		var sealed []string
+		consumed := true
+		for i := 0; i < int(v.Spec.Size); i++ {
+			podName := fmt.Sprintf("%s-%d", v.Name, i)
+			progress, err := r.unsealPodWithKeyShares(ctx, v, podName, shares)
+			if err != nil {
+				// Keep the submitted shares until every pod got them
+				consumed = false
+				sealed = append(sealed, fmt.Sprintf("%s (%v)", podName, err))
+				continue
+			}
+			if progress != "" {
+				sealed = append(sealed, fmt.Sprintf("%s (%s)", podName, progress))
+			}
+		}
+
+		if len(shares) > 0 && consumed {
+			if err := r.wipeKeyShares(ctx, v); err != nil {
+				return err
+			}
+		}
+
+		if len(sealed) > 0 {
+			condition.Status = corev1.ConditionFalse
+			condition.Message = fmt.Sprintf("waiting for key shares in secret %s: %s",
+				v.CustodianSubmissionSecretName(), strings.Join(sealed, ", "))
+		}
+		return nil
+	}()
+	if err != nil {
+		condition.Status = corev1.ConditionFalse
+		condition.Error = err.Error()
+		r.recorder.Event(v, corev1.EventTypeWarning, "CustodianUnsealFailed", err.Error())
+	}
+
+	return condition
+}
+
+// initWithCustodians initialises Vault with one key share per custodian, encrypted with the PGP key
+// of the custodian, and writes every encrypted share to its own Secret
+func (r *ReconcileVault) initWithCustodians(ctx context.Context, v *vaultv1alpha1.Vault, vaultClient *api.Client) error {
+	custodians := v.Spec.UnsealConfig.Custodians.Custodians
+	threshold := v.Spec.UnsealConfig.Options.GetSecretThreshold()
+	if len(custodians) == 0 || threshold > len(custodians) {
+		return fmt.Errorf("the secret threshold of %d needs at least as many custodians, got %d", threshold, len(custodians))
+	}
+
+	pgpKeys := make([]string, 0, len(custodians))
+	for _, custodian := range custodians {
+		pgpKey, err := r.custodianPGPKey(ctx, v, custodian)
+		if err != nil {
+			return err
+		}
+		pgpKeys = append(pgpKeys, pgpKey)
+	}
+
+	resp, err := vaultClient.Sys().InitWithContext(ctx, &api.InitRequest{
+		SecretShares:    len(custodians),
+		SecretThreshold: threshold,
+		PGPKeys:         pgpKeys,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize vault: %v", err)
+	}
+
+	// Vault hands out the shares only once, retry hard to not lose them
+	alwaysRetry := func(error) bool { return true }
+	for i, custodian := range custodians {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      v.CustodianKeySecretName(custodian.Name),
+				Namespace: v.Namespace,
+				Labels:    v.LabelsForVault(),
+			},
+			Data: map[string][]byte{"key": []byte(resp.KeysB64[i])},
+		}
+		err := retry.OnError(retry.DefaultBackoff, alwaysRetry, func() error {
+			return r.createOrUpdateObject(ctx, secret)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store the key share of custodian %s: %v", custodian.Name, err)
+		}
+	}
+
+	// Keep the root token like the unsealer does, the operator and the configurer need it
+	if v.Spec.InitStoresRootToken() {
+		err = retry.OnError(retry.DefaultBackoff, alwaysRetry, func() error {
+			return r.storeUnsealKeys(ctx, v, map[string][]byte{rootTokenKey: []byte(resp.RootToken)})
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store the root token: %v", err)
+		}
+	} else if err := revokeRootToken(ctx, vaultClient, resp.RootToken); err != nil {
+		return err
+	}
+
+	r.recorder.Eventf(v, corev1.EventTypeNormal, "InitializedWithCustodians",
+		"Initialized Vault with the PGP keys of %d custodians and a threshold of %d", len(custodians), threshold)
+	return nil
+}
+
+// custodianPGPKey returns the base64 encoded PGP public key of a custodian
+func (r *ReconcileVault) custodianPGPKey(ctx context.Context, v *vaultv1alpha1.Vault, custodian vaultv1alpha1.Custodian) (string, error) {
+	var pgpKey string
+	switch {
+	case custodian.PGPKeySecretRef != nil:
+		ref := custodian.PGPKeySecretRef
+		secret := corev1.Secret{}
+		if err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: ref.Name}, &secret); err != nil {
+			return "", fmt.Errorf("failed to get PGP key of custodian %s: %v", custodian.Name, err)
+		}
+		pgpKey = string(secret.Data[ref.Key])
+	case custodian.PGPKeyConfigMapRef != nil:
+		ref := custodian.PGPKeyConfigMapRef
+		configMap := corev1.ConfigMap{}
+		if err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: ref.Name}, &configMap); err != nil {
+			return "", fmt.Errorf("failed to get PGP key of custodian %s: %v", custodian.Name, err)
+		}
+		pgpKey = configMap.Data[ref.Key]
+	}
+
+	pgpKey = strings.TrimSpace(pgpKey)
+	if pgpKey == "" {
+		return "", fmt.Errorf("PGP key of custodian %s is missing", custodian.Name)
+	}
+	return pgpKey, nil
+}
+
+// revokeRootToken revokes the root token returned at init when it must not be stored
+func revokeRootToken(ctx context.Context, vaultClient *api.Client, token string) error {
+	vaultClient, err := vaultClient.Clone()
+	if err != nil {
+		return err
+	}
+	vaultClient.SetToken(token)
+	if err := vaultClient.Auth().Token().RevokeSelfWithContext(ctx, ""); err != nil {
+		return fmt.Errorf("failed to revoke the root token: %v", err)
+	}
+	return nil
+}
+
+// storeUnsealKeys adds the values to the Secret of the Kubernetes unseal backend, which the operator
+// and the configurer read the root token from
+func (r *ReconcileVault) storeUnsealKeys(ctx context.Context, v *vaultv1alpha1.Vault, data map[string][]byte) error {
+	secretNamespace, secretName := v.Spec.UnsealConfig.KubernetesSecret(v)
+	secret := corev1.Secret{}
+	err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: secretNamespace, Name: secretName}, &secret)
+	if apierrors.IsNotFound(err) {
+		secret = corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: secretNamespace,
+				Labels:    v.LabelsForVault(),
+			},
+			Data: data,
+		}
+		return r.nonNamespacedClient.Create(ctx, &secret)
+	} else if err != nil {
+		return err
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	for key, value := range data {
+		secret.Data[key] = value
+	}
+	return r.nonNamespacedClient.Update(ctx, &secret)
+}
+
+// submittedKeyShares returns the decrypted key shares submitted by the custodians
+func (r *ReconcileVault) submittedKeyShares(ctx context.Context, v *vaultv1alpha1.Vault) ([]string, error) {
+	secret := corev1.Secret{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: v.CustodianSubmissionSecretName()}, &secret)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get submitted key shares: %v", err)
+	}
+
+	names := make([]string, 0, len(secret.Data))
+	for name := range secret.Data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var shares []string
+	for _, name := range names {
+		if share := strings.TrimSpace(string(secret.Data[name])); share != "" {
+			shares = append(shares, share)
+		}
+	}
+	return shares, nil
+}
+
+// unsealPodWithKeyShares submits the key shares to a sealed Vault pod, and returns the unseal progress
+// if the pod is still sealed afterwards
+func (r *ReconcileVault) unsealPodWithKeyShares(ctx context.Context, v *vaultv1alpha1.Vault, podName string, shares []string) (string, error) {
+	vaultClient, err := r.httpClients.vaultClientForPod(ctx, r.client, v, podName)
+	if err != nil {
+		return "", err
+	}
+
+	sys := vaultClient.Sys()
+	status, err := sys.SealStatusWithContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get seal status: %v", err)
+	}
+
+	// Raft followers have to join the first pod before they can be unsealed
+	if !status.Initialized && v.Spec.IsRaftStorage() && !v.Spec.IsRaftBootstrapFollower() {
+		_, err := sys.RaftJoinWithContext(ctx, &api.RaftJoinRequest{
+			LeaderAPIAddr: podAddressForVault(v, v.Name+"-0"),
+			Retry:         true,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to join raft cluster: %v", err)
+		}
+		if status, err = sys.SealStatusWithContext(ctx); err != nil {
+			return "", fmt.Errorf("failed to get seal status: %v", err)
+		}
+	}
+
+	if !status.Sealed {
+		return "", nil
+	}
+
+	// Vault ignores the shares it has already seen, so every share can be submitted to every pod
+	for _, share := range shares {
+		status, err = sys.UnsealWithContext(ctx, share)
+		if err != nil {
+			return "", fmt.Errorf("failed to submit key share: %v", err)
+		}
+		if !status.Sealed {
+			r.recorder.Eventf(v, corev1.EventTypeNormal, "Unsealed", "Unsealed pod %s", podName)
+			return "", nil
+		}
+	}
+
+	return fmt.Sprintf("%d of %d key shares", status.Progress, status.T), nil
+}
+
+// wipeKeyShares removes the submitted key shares once they have been submitted to every pod
+func (r *ReconcileVault) wipeKeyShares(ctx context.Context, v *vaultv1alpha1.Vault) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret := corev1.Secret{}
+		if err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: v.CustodianSubmissionSecretName()}, &secret); err != nil {
+			return err
+		}
+		secret.Data = nil
+		secret.StringData = nil
+		return r.client.Update(ctx, &secret)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to wipe submitted key shares: %v", err)
+	}
+
+	r.recorder.Event(v, corev1.EventTypeNormal, "KeySharesConsumed", "Submitted the key shares of the custodians and wiped them")
+	return nil
+}
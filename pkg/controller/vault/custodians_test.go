@@ -0,0 +1,112 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestUnsealWithCustodians(t *testing.T) {
+	var initRequest api.InitRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		require.Equal(t, "PUT /v1/sys/init", r.Method+" "+r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&initRequest))
+		_, _ = w.Write([]byte(`{"keys": ["enc-0", "enc-1"], "keys_base64": ["ZW5jLTA=", "ZW5jLTE="], "root_token": "hvs.root"}`))
+	}))
+	defer server.Close()
+
+	vaultClient, err := api.NewClient(&api.Config{Address: server.URL})
+	require.NoError(t, err)
+
+	v := &vaultv1alpha1.Vault{ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"}}
+	v.Spec.UnsealConfig.Options.SecretThreshold = ptr.To[uint](2)
+	v.Spec.UnsealConfig.Custodians = &vaultv1alpha1.CustodianUnsealConfig{
+		Custodians: []vaultv1alpha1.Custodian{
+			{
+				Name: "alice",
+				PGPKeySecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "alice"},
+					Key:                  "key.asc",
+				},
+			},
+			{
+				Name: "bob",
+				PGPKeyConfigMapRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "custodians"},
+					Key:                  "bob",
+				},
+			},
+		},
+	}
+	assert.Equal(t, "custodians", v.Spec.UnsealConfig.Backend())
+	assert.Equal(t, "vault-unseal-submission", v.CustodianSubmissionSecretName())
+
+	r, c := newTestReconciler(t,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "alice", Namespace: "vault"},
+			Data:       map[string][]byte{"key.asc": []byte("YWxpY2U=\n")},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "custodians", Namespace: "vault"},
+			Data:       map[string]string{"bob": "Ym9i"},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "vault-unseal-submission", Namespace: "vault"},
+			Data:       map[string][]byte{"bob": []byte("share-1\n"), "alice": []byte("share-0")},
+		},
+	)
+
+	require.NoError(t, r.initWithCustodians(context.Background(), v, vaultClient))
+	assert.Equal(t, []string{"YWxpY2U=", "Ym9i"}, initRequest.PGPKeys)
+	assert.Equal(t, 2, initRequest.SecretShares)
+	assert.Equal(t, 2, initRequest.SecretThreshold)
+
+	// Every custodian gets its own Secret, the root token is stored for the operator
+	for name, key := range map[string]string{"vault-unseal-key-alice": "ZW5jLTA=", "vault-unseal-key-bob": "ZW5jLTE="} {
+		secret := corev1.Secret{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "vault", Name: name}, &secret))
+		assert.Equal(t, key, string(secret.Data["key"]))
+	}
+	keys := corev1.Secret{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "vault", Name: "vault-unseal-keys"}, &keys))
+	assert.Equal(t, "hvs.root", string(keys.Data["vault-root"]))
+
+	shares, err := r.submittedKeyShares(context.Background(), v)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"share-0", "share-1"}, shares)
+
+	require.NoError(t, r.wipeKeyShares(context.Background(), v))
+	shares, err = r.submittedKeyShares(context.Background(), v)
+	require.NoError(t, err)
+	assert.Empty(t, shares)
+
+	// A threshold above the number of custodians can't be met
+	v.Spec.UnsealConfig.Options.SecretThreshold = ptr.To[uint](3)
+	assert.Error(t, r.initWithCustodians(context.Background(), v, vaultClient))
+}
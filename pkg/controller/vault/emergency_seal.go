@@ -0,0 +1,244 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// emergencySealLifted is the message of the EmergencySealed condition once the emergency seal has been cleared
+const emergencySealLifted = "emergency seal lifted"
+
+// emergencySealLifting is the message of the EmergencySealed condition while the sealed pods are restarted with the unsealer
+const emergencySealLifting = "emergency seal lifted, restarting the sealed pods with the unsealer"
+
+// emergencySeal seals every Vault pod, it returns the EmergencySealed condition which is only true
+// once every pod is sealed, the pods still running the unsealer are restarted once the StatefulSet drops it
+func (r *ReconcileVault) emergencySeal(ctx context.Context, v *vaultv1alpha1.Vault) corev1.ComponentCondition {
+	condition := corev1.ComponentCondition{
+		Type:   vaultv1alpha1.EmergencySealedCondition,
+		Status: corev1.ConditionTrue,
+	}
+
+	var unsealed, errs []string
+	for i := 0; i < int(v.Spec.Size); i++ {
+		podName := fmt.Sprintf("%s-%d", v.Name, i)
+		sealed, err := r.sealPod(ctx, v, podName)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+		if !sealed {
+			unsealed = append(unsealed, podName)
+		}
+	}
+
+	if len(unsealed) > 0 {
+		condition.Status = corev1.ConditionFalse
+		condition.Message = "pods are still unsealed: " + strings.Join(unsealed, ", ")
+	} else {
+		condition.Message = "every pod is sealed"
+	}
+	if len(errs) > 0 {
+		condition.Error = strings.Join(errs, "; ")
+		r.recorder.Event(v, corev1.EventTypeWarning, "EmergencySealFailed", condition.Error)
+	}
+
+	return condition
+}
+
+// sealPod seals a Vault pod through the API, it returns true if the pod is sealed or not running,
+// Vault doesn't seal standby nodes, they are sealed once they become active or are restarted
+func (r *ReconcileVault) sealPod(ctx context.Context, v *vaultv1alpha1.Vault, podName string) (bool, error) {
+	vaultClient, err := r.httpClients.vaultClientForPod(ctx, r.client, v, podName)
+	if err != nil {
+		return false, err
+	}
+
+	status, err := vaultClient.Sys().SealStatusWithContext(ctx)
+	if err != nil {
+		// A pod which doesn't answer can't serve requests either
+		return true, nil
+	}
+	if status.Sealed {
+		return true, nil
+	}
+
+	health, err := vaultClient.Sys().HealthWithContext(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get health of pod %s: %v", podName, err)
+	}
+	if health.Standby {
+		return false, nil
+	}
+
+	adminClient, err := r.httpClients.adminClientForVault(ctx, r.nonNamespacedClient, v, podAddressForVault(v, podName))
+	if err != nil {
+		return false, err
+	}
+	if err := adminClient.Sys().SealWithContext(ctx); err != nil {
+		return false, fmt.Errorf("failed to seal pod %s: %v", podName, err)
+	}
+
+	r.recorder.Eventf(v, corev1.EventTypeNormal, "EmergencySealed", "Sealed pod %s", podName)
+	return true, nil
+}
+
+// restartPodsForEmergencySeal deletes the pods still running with the unsealer, the StatefulSet recreates them
+// without it, so they stay sealed. It is called once the StatefulSet has been updated without the unsealer,
+// and returns true once every pod runs without it.
+func (r *ReconcileVault) restartPodsForEmergencySeal(ctx context.Context, v *vaultv1alpha1.Vault) (bool, error) {
+	statefulSet := appsv1.StatefulSet{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: v.Name}, &statefulSet)
+	if err != nil {
+		return false, fmt.Errorf("failed to get StatefulSet: %v", err)
+	}
+
+	// The update revision is only known once the StatefulSet controller has seen the pod template without the unsealer
+	if hasUnsealerContainer(&statefulSet) || statefulSet.Status.ObservedGeneration < statefulSet.Generation {
+		return false, nil
+	}
+
+	return r.restartPodsOnPreviousRevision(ctx, v, &statefulSet, "EmergencySealed", "Restarting pod %s sealed, without the unsealer")
+}
+
+// hasUnsealerContainer checks if the pod template of the StatefulSet runs the unsealer
+func hasUnsealerContainer(statefulSet *appsv1.StatefulSet) bool {
+	return slices.ContainsFunc(statefulSet.Spec.Template.Spec.Containers, func(c corev1.Container) bool { return c.Name == "bank-vaults" })
+}
+
+// restartPodsOnPreviousRevision deletes the pods which don't run the update revision of the StatefulSet,
+// it returns true once every pod runs it
+func (r *ReconcileVault) restartPodsOnPreviousRevision(ctx context.Context, v *vaultv1alpha1.Vault, statefulSet *appsv1.StatefulSet, reason, messageFmt string) (bool, error) {
+	updated := true
+	for i := 0; i < int(v.Spec.Size); i++ {
+		podName := fmt.Sprintf("%s-%d", v.Name, i)
+		pod := corev1.Pod{}
+		err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: podName}, &pod)
+		if apierrors.IsNotFound(err) {
+			// The StatefulSet recreates it with the update revision
+			updated = false
+			continue
+		} else if err != nil {
+			return false, fmt.Errorf("failed to get pod %s: %v", podName, err)
+		}
+
+		if pod.Labels[appsv1.ControllerRevisionHashLabelKey] == statefulSet.Status.UpdateRevision && pod.DeletionTimestamp == nil {
+			continue
+		}
+		updated = false
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if err := r.client.Delete(ctx, &pod); err != nil && !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to delete pod %s: %v", podName, err)
+		}
+		r.recorder.Eventf(v, corev1.EventTypeNormal, reason, messageFmt, podName)
+	}
+
+	return updated, nil
+}
+
+// reconcileEmergencySeal seals Vault during an emergency seal, and restarts the sealed pods with the unsealer
+// once it is lifted, the status is updated right away, so a failure later in the reconciliation can't skip it
+func (r *ReconcileVault) reconcileEmergencySeal(ctx context.Context, v *vaultv1alpha1.Vault) (reconcile.Result, error) {
+	var result reconcile.Result
+	statusChanged := false
+	if v.IsEmergencySealed() {
+		condition := r.emergencySeal(ctx, v)
+		if v.Status.SetCondition(condition) {
+			statusChanged = true
+			if condition.Status == corev1.ConditionTrue {
+				r.recorder.Event(v, corev1.EventTypeNormal, "EmergencySealed", "Every pod is sealed")
+			}
+		}
+		// Standby pods become active and auto-unsealed pods unseal themselves when restarted
+		result = reconcile.Result{RequeueAfter: 5 * time.Second}
+	} else {
+		lifted, changed := r.liftEmergencySeal(ctx, v)
+		statusChanged = changed
+		if !lifted {
+			result = reconcile.Result{RequeueAfter: 5 * time.Second}
+		}
+	}
+
+	if statusChanged {
+		if err := r.client.Update(ctx, v); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to update vault status: %v", err)
+		}
+	}
+	return result, nil
+}
+
+// liftEmergencySeal restarts the pods kept sealed without the unsealer once the StatefulSet runs it again,
+// the pods are unsealed again by the unsealer or the operator, it returns whether the emergency seal
+// is lifted, and whether the EmergencySealed condition changed
+func (r *ReconcileVault) liftEmergencySeal(ctx context.Context, v *vaultv1alpha1.Vault) (bool, bool) {
+	sealed := v.Status.GetCondition(vaultv1alpha1.EmergencySealedCondition)
+	if sealed == nil || (sealed.Status == corev1.ConditionFalse && sealed.Message == emergencySealLifted) {
+		return true, false
+	}
+
+	condition := corev1.ComponentCondition{
+		Type:    vaultv1alpha1.EmergencySealedCondition,
+		Status:  corev1.ConditionFalse,
+		Message: emergencySealLifted,
+	}
+
+	// The unsealer was only removed from the pods which are not auto-unsealed or unsealed by the operator
+	if !v.Spec.IsAutoUnseal() && !v.Spec.UnsealConfig.OperatorUnseals() {
+		restarted, err := r.restartPodsForLiftedEmergencySeal(ctx, v)
+		if err != nil {
+			condition.Error = err.Error()
+			r.recorder.Event(v, corev1.EventTypeWarning, "EmergencySealLiftFailed", condition.Error)
+		}
+		if !restarted {
+			condition.Message = emergencySealLifting
+			return false, v.Status.SetCondition(condition)
+		}
+	}
+
+	r.recorder.Event(v, corev1.EventTypeNormal, "EmergencySealLifted", "Emergency seal lifted, the pods are unsealed again")
+	return true, v.Status.SetCondition(condition)
+}
+
+// restartPodsForLiftedEmergencySeal deletes the pods still running the sealed revision without the unsealer,
+// once the StatefulSet controller has seen the pod template with the unsealer, it returns true once every pod runs it
+func (r *ReconcileVault) restartPodsForLiftedEmergencySeal(ctx context.Context, v *vaultv1alpha1.Vault) (bool, error) {
+	statefulSet := appsv1.StatefulSet{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: v.Name}, &statefulSet)
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get StatefulSet: %v", err)
+	}
+
+	// The StatefulSet is updated with the unsealer later in the reconciliation
+	if !hasUnsealerContainer(&statefulSet) || statefulSet.Status.ObservedGeneration < statefulSet.Generation {
+		return false, nil
+	}
+
+	return r.restartPodsOnPreviousRevision(ctx, v, &statefulSet, "EmergencySealLifted", "Restarting pod %s with the unsealer")
+}
@@ -0,0 +1,139 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestEmergencySeal(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "vault",
+			Namespace:   "vault",
+			Annotations: map[string]string{vaultv1alpha1.EmergencySealAnnotation: "true"},
+		},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size:   2,
+			Config: extv1beta1.JSON{Raw: []byte(`{"storage": {"raft": {}}}`)},
+		},
+	}
+	require.True(t, v.IsEmergencySealed())
+
+	// The unsealer is removed and the pods are restarted by the operator
+	statefulSet, err := statefulSetForVault(v, nil, map[string]string{}, serviceForVault(v))
+	require.NoError(t, err)
+	assert.Equal(t, appsv1.OnDeleteStatefulSetStrategyType, statefulSet.Spec.UpdateStrategy.Type)
+	for _, container := range statefulSet.Spec.Template.Spec.Containers {
+		assert.NotEqual(t, "bank-vaults", container.Name)
+	}
+
+	// Auto-unsealed pods would unseal themselves when restarted
+	autoUnsealed := v.DeepCopy()
+	autoUnsealed.Spec.UnsealConfig.AWS = &vaultv1alpha1.AWSUnsealConfig{KMSKeyID: "alias/vault"}
+	autoUnsealed.Spec.Config = extv1beta1.JSON{Raw: []byte(`{"storage": {"raft": {}}, "seal": {"awskms": {}}}`)}
+	statefulSet, err = statefulSetForVault(autoUnsealed, nil, map[string]string{}, serviceForVault(autoUnsealed))
+	require.NoError(t, err)
+	assert.Equal(t, appsv1.RollingUpdateStatefulSetStrategyType, statefulSet.Spec.UpdateStrategy.Type)
+
+	pod := func(name, revision string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "vault",
+			Labels:    map[string]string{appsv1.ControllerRevisionHashLabelKey: revision},
+		}}
+	}
+	statefulSet = &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault", Generation: 2},
+		Status:     appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdateRevision: "sealed"},
+	}
+
+	r, c := newTestReconciler(t, statefulSet, pod("vault-0", "unsealer"), pod("vault-1", "sealed"))
+	recorder := r.recorder.(*record.FakeRecorder)
+
+	// Nothing is restarted until the StatefulSet runs without the unsealer
+	withUnsealer := statefulSet.DeepCopy()
+	withUnsealer.Spec.Template.Spec.Containers = []corev1.Container{{Name: "vault"}, {Name: "bank-vaults"}}
+	require.NoError(t, c.Update(context.Background(), withUnsealer))
+	restarted, err := r.restartPodsForEmergencySeal(context.Background(), v)
+	require.NoError(t, err)
+	assert.False(t, restarted)
+	assert.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "vault", Name: "vault-0"}, &corev1.Pod{}))
+	withUnsealer.Spec.Template.Spec.Containers = nil
+	require.NoError(t, c.Update(context.Background(), withUnsealer))
+
+	// Only the pod still running the unsealer is restarted
+	restarted, err = r.restartPodsForEmergencySeal(context.Background(), v)
+	require.NoError(t, err)
+	assert.False(t, restarted)
+	assert.True(t, apierrors.IsNotFound(c.Get(context.Background(), client.ObjectKey{Namespace: "vault", Name: "vault-0"}, &corev1.Pod{})))
+	assert.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "vault", Name: "vault-1"}, &corev1.Pod{}))
+	assert.Equal(t, "Normal EmergencySealed Restarting pod vault-0 sealed, without the unsealer", <-recorder.Events)
+
+	// The sealed pods are restarted once the StatefulSet runs the unsealer again
+	lifted := v.DeepCopy()
+	lifted.Annotations = nil
+	ctx := context.Background()
+	done, changed := r.liftEmergencySeal(ctx, lifted)
+	assert.True(t, done)
+	assert.False(t, changed)
+
+	lifted.Status.SetCondition(corev1.ComponentCondition{Type: vaultv1alpha1.EmergencySealedCondition, Status: corev1.ConditionTrue})
+	done, changed = r.liftEmergencySeal(ctx, lifted)
+	assert.False(t, done)
+	assert.True(t, changed)
+	assert.Equal(t, emergencySealLifting, lifted.Status.GetCondition(vaultv1alpha1.EmergencySealedCondition).Message)
+	assert.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "vault", Name: "vault-1"}, &corev1.Pod{}))
+
+	require.NoError(t, c.Delete(ctx, statefulSet))
+	statefulSet = &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault", Generation: 3},
+		Spec: appsv1.StatefulSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "vault"}, {Name: "bank-vaults"}},
+		}}},
+		Status: appsv1.StatefulSetStatus{ObservedGeneration: 3, UpdateRevision: "unsealer"},
+	}
+	require.NoError(t, c.Create(ctx, statefulSet))
+	done, changed = r.liftEmergencySeal(ctx, lifted)
+	assert.False(t, done)
+	assert.False(t, changed)
+	assert.True(t, apierrors.IsNotFound(c.Get(ctx, client.ObjectKey{Namespace: "vault", Name: "vault-1"}, &corev1.Pod{})))
+	assert.Equal(t, "Normal EmergencySealLifted Restarting pod vault-1 with the unsealer", <-recorder.Events)
+
+	// The emergency seal is lifted once every pod runs the unsealer
+	require.NoError(t, c.Create(ctx, pod("vault-0", "unsealer")))
+	require.NoError(t, c.Create(ctx, pod("vault-1", "unsealer")))
+	done, changed = r.liftEmergencySeal(ctx, lifted)
+	assert.True(t, done)
+	assert.True(t, changed)
+	assert.Equal(t, emergencySealLifted, lifted.Status.GetCondition(vaultv1alpha1.EmergencySealedCondition).Message)
+	assert.Equal(t, "Normal EmergencySealLifted Emergency seal lifted, the pods are unsealed again", <-recorder.Events)
+
+	done, changed = r.liftEmergencySeal(ctx, lifted)
+	assert.True(t, done)
+	assert.False(t, changed)
+}
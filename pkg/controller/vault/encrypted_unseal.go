@@ -0,0 +1,148 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// prepareEncryptedKeys initialises Vault and stores its keys encrypted with the key encryption key,
+// the key decryptor sidecars decrypt them for the unsealer and the configurer
+func (r *ReconcileVault) prepareEncryptedKeys(ctx context.Context, v *vaultv1alpha1.Vault) error {
+	kek, err := keyEncryptionKeyForVault(ctx, r.nonNamespacedClient, v)
+	if err != nil {
+		return err
+	}
+
+	// The other pods join the first one
+	firstPod := v.Name + "-0"
+	vaultClient, err := r.httpClients.vaultClientForPod(ctx, r.client, v, firstPod)
+	if err != nil {
+		return err
+	}
+
+	initialized, err := vaultClient.Sys().InitStatusWithContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get init status of pod %s: %v", firstPod, err)
+	}
+	if !initialized {
+		if err := r.initWithEncryptedKeys(ctx, v, vaultClient, kek); err != nil {
+			return err
+		}
+	}
+
+	// Keys stored in plain form before the encryption was enabled are encrypted in place
+	return r.encryptStoredKeys(ctx, v, kek)
+}
+
+// initWithEncryptedKeys initialises Vault and stores the unseal keys, or the recovery keys of an auto seal,
+// and the root token encrypted with the key encryption key
+func (r *ReconcileVault) initWithEncryptedKeys(ctx context.Context, v *vaultv1alpha1.Vault, vaultClient *api.Client, kek keyEncryptionKey) error {
+	options := v.Spec.UnsealConfig.Options
+	request := &api.InitRequest{
+		SecretShares:    options.GetSecretShares(),
+		SecretThreshold: options.GetSecretThreshold(),
+	}
+	prefix := unsealKeyPrefix
+	if v.Spec.IsAutoUnseal() {
+		request = &api.InitRequest{
+			RecoveryShares:    options.GetRecoveryShares(),
+			RecoveryThreshold: options.GetRecoveryThreshold(),
+		}
+		prefix = recoveryKeyPrefix
+	}
+
+	resp, err := vaultClient.Sys().InitWithContext(ctx, request)
+	if err != nil {
+		return fmt.Errorf("failed to initialize vault: %v", err)
+	}
+
+	keys := resp.Keys
+	if prefix == recoveryKeyPrefix {
+		keys = resp.RecoveryKeys
+	}
+
+	data := map[string][]byte{}
+	for i, key := range keys {
+		if data[fmt.Sprint(prefix, i)], err = sealValue(ctx, kek, []byte(key)); err != nil {
+			return fmt.Errorf("failed to encrypt unseal key: %v", err)
+		}
+	}
+
+	// Keep the root token like the unsealer does, the operator needs it
+	if v.Spec.InitStoresRootToken() {
+		if data[rootTokenKey], err = sealValue(ctx, kek, []byte(resp.RootToken)); err != nil {
+			return fmt.Errorf("failed to encrypt root token: %v", err)
+		}
+	} else if err := revokeRootToken(ctx, vaultClient, resp.RootToken); err != nil {
+		return err
+	}
+
+	// Vault hands out the keys only once, retry hard to not lose them
+	err = retry.OnError(retry.DefaultBackoff, func(error) bool { return true }, func() error {
+		return r.storeUnsealKeys(ctx, v, data)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store the encrypted unseal keys, Vault can't be unsealed without them: %v", err)
+	}
+
+	r.recorder.Eventf(v, corev1.EventTypeNormal, "InitializedWithEncryptedKeys", "Initialized Vault and stored its %d keys encrypted", len(keys))
+	return nil
+}
+
+// encryptStoredKeys encrypts the keys and the root token still stored in plain form in the unseal keys Secret
+func (r *ReconcileVault) encryptStoredKeys(ctx context.Context, v *vaultv1alpha1.Vault, kek keyEncryptionKey) error {
+	secretNamespace, secretName := v.Spec.UnsealConfig.KubernetesSecret(v)
+	secret := corev1.Secret{}
+	err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: secretNamespace, Name: secretName}, &secret)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get unseal keys secret: %v", err)
+	}
+
+	encrypted := 0
+	for key, value := range secret.Data {
+		if key != rootTokenKey && !strings.HasPrefix(key, unsealKeyPrefix) && !strings.HasPrefix(key, recoveryKeyPrefix) {
+			continue
+		}
+		if _, ok := parseEnvelope(value); ok {
+			continue
+		}
+		if secret.Data[key], err = sealValue(ctx, kek, value); err != nil {
+			return fmt.Errorf("failed to encrypt %s: %v", key, err)
+		}
+		encrypted++
+	}
+	if encrypted == 0 {
+		return nil
+	}
+
+	if err := r.nonNamespacedClient.Update(ctx, &secret); err != nil {
+		return fmt.Errorf("failed to update unseal keys secret: %v", err)
+	}
+
+	r.recorder.Eventf(v, corev1.EventTypeNormal, "UnsealKeysEncrypted", "Encrypted %d plain values of secret %s/%s", encrypted, secretNamespace, secretName)
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestEncryptedUnsealKeys(t *testing.T) {
+	kek := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	v := &vaultv1alpha1.Vault{ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"}}
+	v.Spec.UnsealConfig.Kubernetes.Encryption = &vaultv1alpha1.UnsealKeyEncryption{
+		KEKSecretNamespace: "kek",
+		KEKSecretRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "vault-kek"},
+			Key:                  "key",
+		},
+	}
+	assert.False(t, v.Spec.UnsealConfig.OperatorUnseals())
+
+	keys := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-unseal-keys", Namespace: "vault"},
+		Data: map[string][]byte{
+			"vault-unseal-0":        []byte("key-0"),
+			"vault-unseal-staged-0": []byte("staged-0"),
+			"vault-root":            []byte("hvs.root"),
+			"vault-test":            []byte("test"),
+		},
+	}
+
+	r, c := newTestReconciler(t, keys, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-kek", Namespace: "kek"},
+		Data:       map[string][]byte{"key": []byte(kek)},
+	}, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-tls", Namespace: "vault"},
+	})
+
+	// Plain keys are encrypted in place, the unseal Secret alone doesn't reveal them
+	encryption, err := keyEncryptionKeyForVault(context.Background(), c, v)
+	require.NoError(t, err)
+	require.NoError(t, r.encryptStoredKeys(context.Background(), v, encryption))
+
+	stored := corev1.Secret{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(keys), &stored))
+	assert.NotContains(t, string(stored.Data["vault-unseal-0"]), "key-0")
+	assert.NotContains(t, string(stored.Data["vault-root"]), "hvs.root")
+	assert.Equal(t, "test", string(stored.Data["vault-test"]))
+
+	opened, err := r.openKeys(context.Background(), v, storedKeys(&stored, unsealKeyPrefix))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"key-0"}, opened)
+
+	vaultClient, err := r.httpClients.adminClientForVault(context.Background(), c, v, "https://vault-0:8200")
+	require.NoError(t, err)
+	assert.Equal(t, "hvs.root", vaultClient.Token())
+
+	// A wrong key encryption key can't decrypt them
+	_, err = openValue(context.Background(), aesKey([]byte("fedcba9876543210fedcba9876543210")), stored.Data["vault-root"])
+	assert.Error(t, err)
+
+	// The key decryptor sidecar decrypts the keys in use for the unsealer, and only the root token for the configurer
+	require.NoError(t, c.Create(context.Background(), v))
+	for rootTokenOnly, expected := range map[bool]map[string]string{
+		false: {"vault-unseal-0": "key-0", "vault-root": "hvs.root"},
+		true:  {"vault-root": "hvs.root"},
+	} {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "vault-unseal-1"), []byte("removed"), 0o644))
+		require.NoError(t, decryptKeys(context.Background(), c, client.ObjectKeyFromObject(v), dir, rootTokenOnly))
+
+		files, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		decrypted := map[string]string{}
+		for _, file := range files {
+			value, err := os.ReadFile(filepath.Join(dir, file.Name()))
+			require.NoError(t, err)
+			decrypted[file.Name()] = string(value)
+		}
+		if rootTokenOnly {
+			expected["vault-unseal-1"] = "removed"
+		}
+		assert.Equal(t, expected, decrypted)
+	}
+
+	// The unsealer runs in file mode next to the key decryptor, Vault is initialised by the operator
+	statefulSet, err := statefulSetForVault(v, nil, map[string]string{}, serviceForVault(v))
+	require.NoError(t, err)
+	containers := map[string]corev1.Container{}
+	for _, container := range statefulSet.Spec.Template.Spec.Containers {
+		containers[container.Name] = container
+	}
+	require.Contains(t, containers, "key-decryptor")
+	assert.Equal(t, []string{"bank-vaults", "unseal"}, containers["bank-vaults"].Command)
+	assert.Contains(t, containers["bank-vaults"].Args, vaultv1alpha1.DecryptedKeysMountPath)
+
+	configurer, err := deploymentForConfigurer(v, corev1.ConfigMapList{}, corev1.SecretList{}, map[string]string{})
+	require.NoError(t, err)
+	require.Len(t, configurer.Spec.Template.Spec.Containers, 2)
+	assert.Contains(t, configurer.Spec.Template.Spec.Containers[1].Command, "--root-token-only")
+}
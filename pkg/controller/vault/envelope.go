@@ -0,0 +1,261 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	corev1 "k8s.io/api/core/v1"
+	kmsv2 "k8s.io/kms/apis/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// keyEncryptionKey encrypts the data keys of the values stored in the unseal keys Secret
+type keyEncryptionKey interface {
+	wrap(ctx context.Context, dataKey []byte) (*envelope, error)
+	unwrap(ctx context.Context, e *envelope) ([]byte, error)
+}
+
+// envelope is the form of a value stored in an encrypted unseal keys Secret
+type envelope struct {
+	// DataKey is the data key encrypted with the key encryption key
+	DataKey []byte `json:"dataKey"`
+	// KeyID and Annotations are returned by a KMS plugin, they are needed to decrypt the data key
+	KeyID       string            `json:"keyID,omitempty"`
+	Annotations map[string][]byte `json:"annotations,omitempty"`
+	// Ciphertext is the value encrypted with the data key
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// keyEncryptionKeyForVault returns the key encryption key of the unseal keys Secret, nil if it isn't encrypted
+func keyEncryptionKeyForVault(ctx context.Context, c client.Client, v *vaultv1alpha1.Vault) (keyEncryptionKey, error) {
+	if !v.Spec.UnsealConfig.IsEncrypted() {
+		return nil, nil
+	}
+
+	encryption := v.Spec.UnsealConfig.Kubernetes.Encryption
+	switch {
+	case encryption.KMSPluginSocket != "":
+		return &kmsPlugin{socket: encryption.KMSPluginSocket}, nil
+	case encryption.KEKSecretRef != nil:
+		namespace := v.Namespace
+		if encryption.KEKSecretNamespace != "" {
+			namespace = encryption.KEKSecretNamespace
+		}
+		secret := corev1.Secret{}
+		err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: encryption.KEKSecretRef.Name}, &secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get key encryption key secret: %v", err)
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(secret.Data[encryption.KEKSecretRef.Key])))
+		if err != nil || len(key) != 32 {
+			return nil, fmt.Errorf("key encryption key in secret %s/%s is not a base64 encoded 32 byte key", namespace, encryption.KEKSecretRef.Name)
+		}
+		return aesKey(key), nil
+	}
+	return nil, fmt.Errorf("unseal key encryption needs kekSecretRef or kmsPluginSocket")
+}
+
+// sealValue encrypts a value of the unseal keys Secret with a new data key, which is encrypted with the key encryption key
+func sealValue(ctx context.Context, kek keyEncryptionKey, value []byte) ([]byte, error) {
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := aesKey(dataKey).encrypt(value)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := kek.wrap(ctx, dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt data key: %v", err)
+	}
+	e.Ciphertext = ciphertext
+
+	return json.Marshal(e)
+}
+
+// openValue decrypts a value of the unseal keys Secret
+func openValue(ctx context.Context, kek keyEncryptionKey, value []byte) ([]byte, error) {
+	e, ok := parseEnvelope(value)
+	if !ok {
+		return nil, fmt.Errorf("value is not encrypted")
+	}
+
+	dataKey, err := kek.unwrap(ctx, e)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data key: %v", err)
+	}
+
+	return aesKey(dataKey).decrypt(e.Ciphertext)
+}
+
+func parseEnvelope(value []byte) (*envelope, bool) {
+	e := envelope{}
+	if err := json.Unmarshal(value, &e); err != nil || len(e.DataKey) == 0 || len(e.Ciphertext) == 0 {
+		return nil, false
+	}
+	return &e, true
+}
+
+// openKeys decrypts the keys read from the unseal keys Secret, they are returned as stored if it isn't encrypted
+func (r *ReconcileVault) openKeys(ctx context.Context, v *vaultv1alpha1.Vault, keys []string) ([]string, error) {
+	kek, err := keyEncryptionKeyForVault(ctx, r.nonNamespacedClient, v)
+	if err != nil || kek == nil {
+		return keys, err
+	}
+
+	opened := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value, err := openValue(ctx, kek, []byte(key))
+		if err != nil {
+			return nil, err
+		}
+		opened = append(opened, string(value))
+	}
+	return opened, nil
+}
+
+// sealKeys encrypts the keys to store in the unseal keys Secret, they are returned as is if it isn't encrypted
+func (r *ReconcileVault) sealKeys(ctx context.Context, v *vaultv1alpha1.Vault, keys []string) ([][]byte, error) {
+	kek, err := keyEncryptionKeyForVault(ctx, r.nonNamespacedClient, v)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		value := []byte(key)
+		if kek != nil {
+			if value, err = sealValue(ctx, kek, value); err != nil {
+				return nil, err
+			}
+		}
+		sealed = append(sealed, value)
+	}
+	return sealed, nil
+}
+
+// aesKey encrypts with AES-256-GCM, the nonce is prepended to the ciphertext
+type aesKey []byte
+
+func (k aesKey) encrypt(plaintext []byte) ([]byte, error) {
+	gcm, err := k.gcm()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (k aesKey) decrypt(ciphertext []byte) ([]byte, error) {
+	gcm, err := k.gcm()
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext is too short")
+	}
+	return gcm.Open(nil, ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():], nil)
+}
+
+func (k aesKey) gcm() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(k)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (k aesKey) wrap(_ context.Context, dataKey []byte) (*envelope, error) {
+	wrapped, err := k.encrypt(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return &envelope{DataKey: wrapped}, nil
+}
+
+func (k aesKey) unwrap(_ context.Context, e *envelope) ([]byte, error) {
+	return k.decrypt(e.DataKey)
+}
+
+// kmsPlugin encrypts with a Kubernetes KMS v2 plugin
+type kmsPlugin struct {
+	socket string
+}
+
+func (k *kmsPlugin) wrap(ctx context.Context, dataKey []byte) (*envelope, error) {
+	var response *kmsv2.EncryptResponse
+	err := k.call(func(service kmsv2.KeyManagementServiceClient) (err error) {
+		response, err = service.Encrypt(ctx, &kmsv2.EncryptRequest{Plaintext: dataKey, Uid: newRequestUID()})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("KMS plugin Encrypt failed: %v", err)
+	}
+	if len(response.Ciphertext) == 0 {
+		return nil, fmt.Errorf("KMS plugin returned an empty ciphertext")
+	}
+	return &envelope{DataKey: response.Ciphertext, KeyID: response.KeyId, Annotations: response.Annotations}, nil
+}
+
+func (k *kmsPlugin) unwrap(ctx context.Context, e *envelope) ([]byte, error) {
+	var response *kmsv2.DecryptResponse
+	err := k.call(func(service kmsv2.KeyManagementServiceClient) (err error) {
+		response, err = service.Decrypt(ctx, &kmsv2.DecryptRequest{
+			Ciphertext:  e.DataKey,
+			Uid:         newRequestUID(),
+			KeyId:       e.KeyID,
+			Annotations: e.Annotations,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("KMS plugin Decrypt failed: %v", err)
+	}
+	return response.Plaintext, nil
+}
+
+func (k *kmsPlugin) call(fn func(kmsv2.KeyManagementServiceClient) error) error {
+	conn, err := grpc.NewClient("unix://"+k.socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to connect to KMS plugin: %v", err)
+	}
+	defer conn.Close()
+
+	return fn(kmsv2.NewKeyManagementServiceClient(conn))
+}
+
+func newRequestUID() string {
+	uid := make([]byte, 16)
+	_, _ = rand.Read(uid)
+	return hex.EncodeToString(uid)
+}
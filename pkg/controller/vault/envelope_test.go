@@ -0,0 +1,75 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	kmsv2 "k8s.io/kms/apis/v2"
+)
+
+// fakeKMSPlugin xors with its key and requires its key ID and annotation back
+type fakeKMSPlugin struct {
+	kmsv2.UnimplementedKeyManagementServiceServer
+}
+
+func (fakeKMSPlugin) xor(data []byte) []byte {
+	out := make([]byte, len(data))
+	for i := range data {
+		out[i] = data[i] ^ 0x5a
+	}
+	return out
+}
+
+func (p fakeKMSPlugin) Encrypt(_ context.Context, request *kmsv2.EncryptRequest) (*kmsv2.EncryptResponse, error) {
+	return &kmsv2.EncryptResponse{
+		Ciphertext:  p.xor(request.Plaintext),
+		KeyId:       "key-1",
+		Annotations: map[string][]byte{"kms.example.com/region": []byte("eu")},
+	}, nil
+}
+
+func (p fakeKMSPlugin) Decrypt(_ context.Context, request *kmsv2.DecryptRequest) (*kmsv2.DecryptResponse, error) {
+	if request.KeyId != "key-1" || string(request.Annotations["kms.example.com/region"]) != "eu" {
+		return nil, fmt.Errorf("unexpected key ID %q or annotations %v", request.KeyId, request.Annotations)
+	}
+	return &kmsv2.DecryptResponse{Plaintext: p.xor(request.Ciphertext)}, nil
+}
+
+func TestKMSPluginEnvelope(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "kms.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	kmsv2.RegisterKeyManagementServiceServer(server, &fakeKMSPlugin{})
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	plugin := &kmsPlugin{socket: socket}
+	sealed, err := sealValue(context.Background(), plugin, []byte("hvs.root"))
+	require.NoError(t, err)
+
+	opened, err := openValue(context.Background(), plugin, sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "hvs.root", string(opened))
+}
@@ -0,0 +1,649 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	"github.com/spf13/cast"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// externalConfig is the subset of the Bank-Vaults configurer format the operator can apply by itself
+type externalConfig struct {
+	Policies       []externalPolicy        `json:"policies,omitempty"`
+	Auth           []externalAuth          `json:"auth,omitempty"`
+	Secrets        []externalSecretEngine  `json:"secrets,omitempty"`
+	Audit          []externalAudit         `json:"audit,omitempty"`
+	StartupSecrets []externalStartupSecret `json:"startupSecrets,omitempty"`
+}
+
+type externalPolicy struct {
+	Name  string `json:"name"`
+	Rules string `json:"rules"`
+}
+
+type externalAuth struct {
+	Type        string                   `json:"type"`
+	Path        string                   `json:"path,omitempty"`
+	Description string                   `json:"description,omitempty"`
+	Options     map[string]interface{}   `json:"options,omitempty"`
+	Config      map[string]interface{}   `json:"config,omitempty"`
+	Roles       []map[string]interface{} `json:"roles,omitempty"`
+}
+
+type externalSecretEngine struct {
+	Type          string                              `json:"type"`
+	Path          string                              `json:"path,omitempty"`
+	Description   string                              `json:"description,omitempty"`
+	Local         bool                                `json:"local,omitempty"`
+	SealWrap      bool                                `json:"sealWrap,omitempty"`
+	Options       map[string]interface{}              `json:"options,omitempty"`
+	Config        map[string]interface{}              `json:"config,omitempty"`
+	Configuration map[string][]map[string]interface{} `json:"configuration,omitempty"`
+}
+
+type externalAudit struct {
+	Type        string                 `json:"type"`
+	Path        string                 `json:"path,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Local       bool                   `json:"local,omitempty"`
+	Options     map[string]interface{} `json:"options,omitempty"`
+}
+
+type externalStartupSecret struct {
+	Type string                 `json:"type"`
+	Path string                 `json:"path"`
+	Data map[string]interface{} `json:"data"`
+}
+
+func parseExternalConfig(raw []byte) (*externalConfig, error) {
+	var config externalConfig
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse external config: %v", err)
+	}
+	return &config, nil
+}
+
+func externalConfigHash(v *vaultv1alpha1.Vault) string {
+	return fmt.Sprintf("%x", sha256.Sum256(v.Spec.ExternalConfigJSON()))
+}
+
+func mountPath(path, typ string) string {
+	if path == "" {
+		path = typ
+	}
+	return strings.Trim(path, "/")
+}
+
+func mountConfigInput(config map[string]interface{}) api.MountConfigInput {
+	return api.MountConfigInput{
+		DefaultLeaseTTL:           cast.ToString(config["default_lease_ttl"]),
+		MaxLeaseTTL:               cast.ToString(config["max_lease_ttl"]),
+		ForceNoCache:              cast.ToBool(config["force_no_cache"]),
+		ListingVisibility:         cast.ToString(config["listing_visibility"]),
+		AuditNonHMACRequestKeys:   cast.ToStringSlice(config["audit_non_hmac_request_keys"]),
+		AuditNonHMACResponseKeys:  cast.ToStringSlice(config["audit_non_hmac_response_keys"]),
+		PassthroughRequestHeaders: cast.ToStringSlice(config["passthrough_request_headers"]),
+		AllowedResponseHeaders:    cast.ToStringSlice(config["allowed_response_headers"]),
+	}
+}
+
+// applyExternalConfig applies the ExternalConfig through the Vault API, every step is idempotent
+func applyExternalConfig(vaultClient *api.Client, config *externalConfig) error {
+	for _, policy := range config.Policies {
+		if err := vaultClient.Sys().PutPolicy(policy.Name, policy.Rules); err != nil {
+			return fmt.Errorf("failed to put policy %s: %v", policy.Name, err)
+		}
+	}
+
+	if err := applyAuth(vaultClient, config.Auth); err != nil {
+		return err
+	}
+
+	if err := applySecretEngines(vaultClient, config.Secrets); err != nil {
+		return err
+	}
+
+	if err := applyAudit(vaultClient, config.Audit); err != nil {
+		return err
+	}
+
+	for _, startupSecret := range config.StartupSecrets {
+		if startupSecret.Type != "kv" {
+			return fmt.Errorf("unsupported startup secret type: %s", startupSecret.Type)
+		}
+		if _, err := vaultClient.Logical().Write(startupSecret.Path, startupSecret.Data); err != nil {
+			return fmt.Errorf("failed to write startup secret %s: %v", startupSecret.Path, err)
+		}
+	}
+
+	return nil
+}
+
+func applyAuth(vaultClient *api.Client, auths []externalAuth) error {
+	existing, err := vaultClient.Sys().ListAuth()
+	if err != nil {
+		return fmt.Errorf("failed to list auth methods: %v", err)
+	}
+
+	for _, auth := range auths {
+		path := mountPath(auth.Path, auth.Type)
+		if _, ok := existing[path+"/"]; !ok {
+			err := vaultClient.Sys().EnableAuthWithOptions(path, &api.EnableAuthOptions{
+				Type:        auth.Type,
+				Description: auth.Description,
+				Config:      mountConfigInput(auth.Options),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to enable %s auth method at %s: %v", auth.Type, path, err)
+			}
+		} else if len(auth.Options) != 0 {
+			if err := vaultClient.Sys().TuneMount("auth/"+path, mountConfigInput(auth.Options)); err != nil {
+				return fmt.Errorf("failed to tune auth method %s: %v", path, err)
+			}
+		}
+
+		if len(auth.Config) != 0 {
+			if _, err := vaultClient.Logical().Write("auth/"+path+"/config", auth.Config); err != nil {
+				return fmt.Errorf("failed to configure auth method %s: %v", path, err)
+			}
+		}
+
+		for _, role := range auth.Roles {
+			name := cast.ToString(role["name"])
+			if name == "" {
+				return fmt.Errorf("role without name in auth method %s", path)
+			}
+			if _, err := vaultClient.Logical().Write("auth/"+path+"/role/"+name, role); err != nil {
+				return fmt.Errorf("failed to write role %s of auth method %s: %v", name, path, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func applySecretEngines(vaultClient *api.Client, engines []externalSecretEngine) error {
+	existing, err := vaultClient.Sys().ListMounts()
+	if err != nil {
+		return fmt.Errorf("failed to list secret engines: %v", err)
+	}
+
+	for _, engine := range engines {
+		path := mountPath(engine.Path, engine.Type)
+		if _, ok := existing[path+"/"]; !ok {
+			err := vaultClient.Sys().Mount(path, &api.MountInput{
+				Type:        engine.Type,
+				Description: engine.Description,
+				Local:       engine.Local,
+				SealWrap:    engine.SealWrap,
+				Options:     cast.ToStringMapString(engine.Options),
+				Config:      mountConfigInput(engine.Config),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to mount %s secret engine at %s: %v", engine.Type, path, err)
+			}
+		} else if len(engine.Config) != 0 || len(engine.Options) != 0 {
+			// The options, like the version of kv, are tuned along with the config
+			config := mountConfigInput(engine.Config)
+			config.Options = cast.ToStringMapString(engine.Options)
+			if err := vaultClient.Sys().TuneMount(path, config); err != nil {
+				return fmt.Errorf("failed to tune secret engine %s: %v", path, err)
+			}
+		}
+
+		for key, items := range engine.Configuration {
+			for _, item := range items {
+				itemPath := path + "/" + key
+				if name := cast.ToString(item["name"]); name != "" {
+					itemPath += "/" + name
+				}
+				if _, err := vaultClient.Logical().Write(itemPath, item); err != nil {
+					return fmt.Errorf("failed to write secret engine configuration %s: %v", itemPath, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func applyAudit(vaultClient *api.Client, audits []externalAudit) error {
+	existing, err := vaultClient.Sys().ListAudit()
+	if err != nil {
+		return fmt.Errorf("failed to list audit devices: %v", err)
+	}
+
+	for _, audit := range audits {
+		path := mountPath(audit.Path, audit.Type)
+		if device, ok := existing[path+"/"]; ok {
+			if !auditDeviceDrifted(device, audit) {
+				continue
+			}
+			// Audit devices can't be tuned, a changed one is enabled again
+			if err := vaultClient.Sys().DisableAudit(path); err != nil {
+				return fmt.Errorf("failed to disable audit device %s: %v", path, err)
+			}
+		}
+		err := vaultClient.Sys().EnableAuditWithOptions(path, &api.EnableAuditOptions{
+			Type:        audit.Type,
+			Description: audit.Description,
+			Local:       audit.Local,
+			Options:     cast.ToStringMapString(audit.Options),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to enable %s audit device at %s: %v", audit.Type, path, err)
+		}
+	}
+
+	return nil
+}
+
+// auditDeviceDrifted tells whether an enabled audit device differs from the declared one
+func auditDeviceDrifted(device *api.Audit, audit externalAudit) bool {
+	if device.Type != audit.Type {
+		return true
+	}
+	for key, value := range cast.ToStringMapString(audit.Options) {
+		if device.Options[key] != value {
+			return true
+		}
+	}
+	return false
+}
+
+// detectDrift compares Vault with the ExternalConfig and returns the differences
+func detectDrift(vaultClient *api.Client, config *externalConfig) ([]string, error) {
+	var drift []string
+
+	for _, policy := range config.Policies {
+		rules, err := vaultClient.Sys().GetPolicy(policy.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read policy %s: %v", policy.Name, err)
+		}
+		if rules == "" {
+			drift = append(drift, fmt.Sprintf("policy %s is missing", policy.Name))
+		} else if strings.TrimSpace(rules) != strings.TrimSpace(policy.Rules) {
+			drift = append(drift, fmt.Sprintf("policy %s differs", policy.Name))
+		}
+	}
+
+	mounts, err := vaultClient.Sys().ListMounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secret engines: %v", err)
+	}
+	for _, engine := range config.Secrets {
+		path := mountPath(engine.Path, engine.Type)
+		mount, ok := mounts[path+"/"]
+		if !ok {
+			drift = append(drift, fmt.Sprintf("secret engine %s is missing", path))
+			continue
+		}
+		if mount.Type != engine.Type {
+			drift = append(drift, fmt.Sprintf("secret engine %s has type %s instead of %s", path, mount.Type, engine.Type))
+		}
+		for key, value := range cast.ToStringMapString(engine.Options) {
+			if mount.Options[key] != value {
+				drift = append(drift, fmt.Sprintf("secret engine %s has option %s=%q instead of %q", path, key, mount.Options[key], value))
+			}
+		}
+
+		engineDrift, err := mountTuneDrift(vaultClient, "sys/mounts/"+path+"/tune", engine.Config)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range engineDrift {
+			drift = append(drift, fmt.Sprintf("secret engine %s differs in %s", path, key))
+		}
+
+		for key, items := range engine.Configuration {
+			for _, item := range items {
+				itemPath := path + "/" + key
+				if name := cast.ToString(item["name"]); name != "" {
+					itemPath += "/" + name
+				}
+				// Write only endpoints, like pki/root/generate, can't be compared
+				secret, err := vaultClient.Logical().Read(itemPath)
+				var responseErr *api.ResponseError
+				if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusMethodNotAllowed {
+					continue
+				} else if err != nil {
+					return nil, fmt.Errorf("failed to read secret engine configuration %s: %v", itemPath, err)
+				}
+				if secret == nil {
+					drift = append(drift, fmt.Sprintf("secret engine configuration %s is missing", itemPath))
+					continue
+				}
+				for _, field := range driftedFields(item, secret.Data) {
+					drift = append(drift, fmt.Sprintf("secret engine configuration %s differs in %s", itemPath, field))
+				}
+			}
+		}
+	}
+
+	auths, err := vaultClient.Sys().ListAuth()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list auth methods: %v", err)
+	}
+	for _, auth := range config.Auth {
+		path := mountPath(auth.Path, auth.Type)
+		mount, ok := auths[path+"/"]
+		if !ok {
+			drift = append(drift, fmt.Sprintf("auth method %s is missing", path))
+			continue
+		}
+		if mount.Type != auth.Type {
+			drift = append(drift, fmt.Sprintf("auth method %s has type %s instead of %s", path, mount.Type, auth.Type))
+		}
+
+		authDrift, err := mountTuneDrift(vaultClient, "sys/auth/"+path+"/tune", auth.Options)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range authDrift {
+			drift = append(drift, fmt.Sprintf("auth method %s differs in %s", path, key))
+		}
+
+		if len(auth.Config) != 0 {
+			secret, err := vaultClient.Logical().Read("auth/" + path + "/config")
+			if err != nil {
+				return nil, fmt.Errorf("failed to read config of auth method %s: %v", path, err)
+			}
+			if secret == nil {
+				drift = append(drift, fmt.Sprintf("config of auth method %s is missing", path))
+			} else {
+				for _, key := range driftedFields(auth.Config, secret.Data) {
+					drift = append(drift, fmt.Sprintf("config of auth method %s differs in %s", path, key))
+				}
+			}
+		}
+
+		for _, role := range auth.Roles {
+			name := cast.ToString(role["name"])
+			secret, err := vaultClient.Logical().Read("auth/" + path + "/role/" + name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read role %s of auth method %s: %v", name, path, err)
+			}
+			if secret == nil {
+				drift = append(drift, fmt.Sprintf("role %s of auth method %s is missing", name, path))
+				continue
+			}
+			for _, key := range driftedFields(role, secret.Data) {
+				drift = append(drift, fmt.Sprintf("role %s of auth method %s differs in %s", name, path, key))
+			}
+		}
+	}
+
+	audits, err := vaultClient.Sys().ListAudit()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit devices: %v", err)
+	}
+	for _, audit := range config.Audit {
+		path := mountPath(audit.Path, audit.Type)
+		device, ok := audits[path+"/"]
+		if !ok {
+			drift = append(drift, fmt.Sprintf("audit device %s is missing", path))
+			continue
+		}
+		if device.Type != audit.Type {
+			drift = append(drift, fmt.Sprintf("audit device %s has type %s instead of %s", path, device.Type, audit.Type))
+		}
+		for key, value := range cast.ToStringMapString(audit.Options) {
+			if device.Options[key] != value {
+				drift = append(drift, fmt.Sprintf("audit device %s has option %s=%q instead of %q", path, key, device.Options[key], value))
+			}
+		}
+	}
+
+	sort.Strings(drift)
+
+	return drift, nil
+}
+
+// mountTuneDrift returns the tune settings of a mount which differ from the declared ones
+func mountTuneDrift(vaultClient *api.Client, tunePath string, declared map[string]interface{}) ([]string, error) {
+	if len(declared) == 0 {
+		return nil, nil
+	}
+	secret, err := vaultClient.Logical().Read(tunePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", tunePath, err)
+	}
+	if secret == nil {
+		return nil, nil
+	}
+	return driftedFields(declared, secret.Data), nil
+}
+
+// driftedFields returns the sorted keys of the declared fields Vault has another value for,
+// fields Vault doesn't return, like secrets, can't be compared and are skipped
+func driftedFields(declared, actual map[string]interface{}) []string {
+	var fields []string
+	for key, value := range declared {
+		if key == "name" {
+			continue
+		}
+		current, ok := actual[key]
+		if !ok {
+			continue
+		}
+		if !sameValue(value, current) {
+			fields = append(fields, key)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// sameValue compares a declared value with the one Vault returns, which has durations in seconds
+// and lists where comma separated strings are accepted
+func sameValue(declared, actual interface{}) bool {
+	if duration, ok := declared.(string); ok {
+		if d, err := time.ParseDuration(duration); err == nil {
+			if seconds, err := cast.ToInt64E(actual); err == nil {
+				return int64(d.Seconds()) == seconds
+			}
+		}
+	}
+
+	if _, ok := actual.([]interface{}); ok {
+		return slices.Equal(sortedValues(declared), sortedValues(actual))
+	}
+
+	return cast.ToString(declared) == cast.ToString(actual)
+}
+
+func sortedValues(value interface{}) []string {
+	var values []string
+	if list, ok := value.(string); ok {
+		for _, item := range strings.Split(list, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+	} else {
+		values = cast.ToStringSlice(value)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// podAddressForVault returns the API address of a Vault pod
+func podAddressForVault(v *vaultv1alpha1.Vault, podName string) string {
+	return strings.ToLower(string(getVaultURIScheme(v))) + "://" + net.JoinHostPort(podFQDN(v, podName), apiPort(v))
+}
+
+// vaultClientForPod returns an unauthenticated Vault client of a Vault pod
+func (cache *vaultHTTPClientCache) vaultClientForPod(ctx context.Context, c client.Client, v *vaultv1alpha1.Vault, podName string) (*api.Client, error) {
+	return cache.newVaultClient(ctx, c, v, podAddressForVault(v, podName))
+}
+
+// adminClientForVault returns a Vault client authenticated with the identity of the operator once it is bootstrapped,
+// or with the root token stored by the unsealer
+func (cache *vaultHTTPClientCache) adminClientForVault(ctx context.Context, c client.Client, v *vaultv1alpha1.Vault, address string) (*api.Client, error) {
+	vaultClient, err := cache.newVaultClient(ctx, c, v, address)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.Spec.OperatorIdentity != nil && v.Status.OperatorIdentityHash != "" {
+		if err := cache.authenticateOperator(ctx, vaultClient, v); err != nil {
+			return nil, err
+		}
+		return vaultClient, nil
+	}
+
+	token, err := rootTokenForVault(ctx, c, v)
+	if err != nil {
+		return nil, err
+	}
+	vaultClient.SetToken(token)
+
+	return vaultClient, nil
+}
+
+// rootTokenForVault returns the root token stored by the unsealer, decrypted if the unseal keys are encrypted
+func rootTokenForVault(ctx context.Context, c client.Client, v *vaultv1alpha1.Vault) (string, error) {
+	if !v.Spec.UnsealConfig.IsKubernetes() && v.Spec.UnsealConfig.Custodians == nil {
+		return "", fmt.Errorf("the operator needs the root token in a Kubernetes Secret to configure Vault")
+	}
+
+	secretNamespace, secretName := v.Spec.UnsealConfig.KubernetesSecret(v)
+	secret := corev1.Secret{}
+	err := c.Get(ctx, client.ObjectKey{Namespace: secretNamespace, Name: secretName}, &secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to get unseal keys secret: %v", err)
+	}
+
+	token, ok := secret.Data[rootTokenKey]
+	if !ok {
+		return "", fmt.Errorf("root token is missing from secret %s/%s", secretNamespace, secretName)
+	}
+
+	kek, err := keyEncryptionKeyForVault(ctx, c, v)
+	if err != nil {
+		return "", err
+	}
+	if kek != nil {
+		if token, err = openValue(ctx, kek, token); err != nil {
+			return "", fmt.Errorf("failed to decrypt root token: %v", err)
+		}
+	}
+
+	return string(token), nil
+}
+
+// configureVault applies the ExternalConfig to the leader and returns the resulting condition
+func (r *ReconcileVault) configureVault(ctx context.Context, v *vaultv1alpha1.Vault, leader string) corev1.ComponentCondition {
+	condition := corev1.ComponentCondition{
+		Type:   vaultv1alpha1.ConfigurationAppliedCondition,
+		Status: corev1.ConditionTrue,
+	}
+
+	err := func() error {
+		config, err := parseExternalConfig(v.Spec.ExternalConfigJSON())
+		if err != nil {
+			return err
+		}
+
+		vaultClient, err := r.httpClients.adminClientForVault(ctx, r.nonNamespacedClient, v, podAddressForVault(v, leader))
+		if err != nil {
+			return err
+		}
+
+		return applyExternalConfig(vaultClient, config)
+	}()
+	if err != nil {
+		condition.Status = corev1.ConditionFalse
+		condition.Error = err.Error()
+	}
+
+	return condition
+}
+
+// checkDrift compares the leader with the ExternalConfig, reverts the drift in enforce mode,
+// and returns the resulting condition with the remaining differences
+func (r *ReconcileVault) checkDrift(ctx context.Context, v *vaultv1alpha1.Vault, leader string) (corev1.ComponentCondition, []string) {
+	condition := corev1.ComponentCondition{
+		Type:   vaultv1alpha1.ConfigurationInSyncCondition,
+		Status: corev1.ConditionTrue,
+	}
+
+	drift, err := func() ([]string, error) {
+		config, err := parseExternalConfig(v.Spec.ExternalConfigJSON())
+		if err != nil {
+			return nil, err
+		}
+
+		vaultClient, err := r.httpClients.adminClientForVault(ctx, r.nonNamespacedClient, v, podAddressForVault(v, leader))
+		if err != nil {
+			return nil, err
+		}
+
+		drift, err := detectDrift(vaultClient, config)
+		if err != nil || len(drift) == 0 || !v.Spec.DriftDetection.Enforce {
+			return drift, err
+		}
+
+		log.Info("reverting drift from external config", "vault", v.Name, "drift", drift)
+		if err := applyExternalConfig(vaultClient, config); err != nil {
+			return drift, err
+		}
+
+		return detectDrift(vaultClient, config)
+	}()
+	if err != nil {
+		condition.Status = corev1.ConditionFalse
+		condition.Error = err.Error()
+	} else if len(drift) != 0 {
+		condition.Status = corev1.ConditionFalse
+		condition.Message = fmt.Sprintf("%d difference(s) found between Vault and the external config", len(drift))
+	}
+
+	return condition, drift
+}
+
+// removeConfigurer deletes the configurer Deployment and Service left behind by the deployment configurer mode
+func (r *ReconcileVault) removeConfigurer(ctx context.Context, v *vaultv1alpha1.Vault) error {
+	objectMeta := metav1.ObjectMeta{Name: v.Name + "-configurer", Namespace: v.Namespace}
+
+	err := r.client.Delete(ctx, &appsv1.Deployment{ObjectMeta: objectMeta})
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete configurer deployment: %v", err)
+	}
+
+	err = r.client.Delete(ctx, &corev1.Service{ObjectMeta: objectMeta})
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete configurer service: %v", err)
+	}
+
+	return nil
+}
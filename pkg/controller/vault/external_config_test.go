@@ -0,0 +1,214 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyExternalConfig(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodGet {
+			w.Header().Set("Content-Type", "application/json")
+			// The kv secret engine is already mounted, everything else is missing
+			_, _ = w.Write([]byte(`{"data": {"secret/": {"type": "kv"}}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	vaultClient, err := api.NewClient(&api.Config{Address: server.URL})
+	require.NoError(t, err)
+
+	config, err := parseExternalConfig([]byte(`{
+		"policies": [{"name": "allow_secrets", "rules": "path \"secret/*\" { capabilities = [\"read\"] }"}],
+		"auth": [{"type": "kubernetes", "roles": [{"name": "default", "policies": "allow_secrets"}]}],
+		"secrets": [
+			{"type": "kv", "path": "secret", "options": {"version": 2}},
+			{"type": "database", "configuration": {"roles": [{"name": "app", "db_name": "mysql"}]}}
+		],
+		"audit": [{"type": "file", "options": {"file_path": "/tmp/vault.log"}}],
+		"startupSecrets": [{"type": "kv", "path": "secret/data/app", "data": {"data": {"key": "value"}}}]
+	}`))
+	require.NoError(t, err)
+
+	require.NoError(t, applyExternalConfig(vaultClient, config))
+
+	assert.Equal(t, []string{
+		"PUT /v1/sys/policies/acl/allow_secrets",
+		"GET /v1/sys/auth",
+		"POST /v1/sys/auth/kubernetes",
+		"PUT /v1/auth/kubernetes/role/default",
+		"GET /v1/sys/mounts",
+		"POST /v1/sys/mounts/secret/tune",
+		"POST /v1/sys/mounts/database",
+		"PUT /v1/database/roles/app",
+		"GET /v1/sys/audit",
+		"PUT /v1/sys/audit/file",
+		"PUT /v1/secret/data/app",
+	}, requests)
+}
+
+func TestApplyExternalConfigRevertsDrift(t *testing.T) {
+	responses := map[string]string{
+		"/v1/sys/auth":   `{"data": {"kubernetes/": {"type": "kubernetes"}}}`,
+		"/v1/sys/mounts": `{"data": {"secret/": {"type": "kv", "options": {"version": "1"}}}}`,
+		"/v1/sys/audit":  `{"data": {"file/": {"type": "file", "options": {"file_path": "/tmp/audit.log"}}, "syslog/": {"type": "syslog"}}}`,
+	}
+	var requests []string
+	var tunes []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodGet {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(responses[r.URL.Path]))
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/tune") {
+			var tune map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&tune))
+			tunes = append(tunes, tune)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	vaultClient, err := api.NewClient(&api.Config{Address: server.URL})
+	require.NoError(t, err)
+
+	config, err := parseExternalConfig([]byte(`{
+		"auth": [{"type": "kubernetes", "options": {"default_lease_ttl": "1h"}}],
+		"secrets": [{"type": "kv", "path": "secret", "options": {"version": 2}}],
+		"audit": [
+			{"type": "file", "options": {"file_path": "/vault/logs/audit.log"}},
+			{"type": "syslog"}
+		]
+	}`))
+	require.NoError(t, err)
+
+	require.NoError(t, applyExternalConfig(vaultClient, config))
+
+	// The existing mounts are tuned, the changed audit device is enabled again
+	assert.Equal(t, []string{
+		"GET /v1/sys/auth",
+		"POST /v1/sys/mounts/auth/kubernetes/tune",
+		"GET /v1/sys/mounts",
+		"POST /v1/sys/mounts/secret/tune",
+		"GET /v1/sys/audit",
+		"DELETE /v1/sys/audit/file",
+		"PUT /v1/sys/audit/file",
+	}, requests)
+	require.Len(t, tunes, 2)
+	assert.Equal(t, "1h", tunes[0]["default_lease_ttl"])
+	assert.Equal(t, map[string]interface{}{"version": "2"}, tunes[1]["options"])
+}
+
+func TestDetectDrift(t *testing.T) {
+	responses := map[string]string{
+		"/v1/sys/policies/acl/allow_secrets": `{"data": {"policy": "path \"secret/*\" { capabilities = [\"list\"] }"}}`,
+		"/v1/sys/mounts":                     `{"data": {"secret/": {"type": "kv", "options": {"version": "1"}}, "pki/": {"type": "pki"}, "database/": {"type": "database"}}}`,
+		"/v1/sys/mounts/pki/tune":            `{"data": {"default_lease_ttl": 604800, "max_lease_ttl": 2592000}}`,
+		"/v1/pki/roles/default":              `{"data": {"allowed_domains": ["localhost", "svc"], "ttl": 60}}`,
+		"/v1/sys/auth":                       `{"data": {"kubernetes/": {"type": "kubernetes"}}}`,
+		"/v1/auth/kubernetes/config":         `{"data": {"kubernetes_host": "https://10.0.0.1"}}`,
+		"/v1/auth/kubernetes/role/default":   `{"data": {"policies": ["allow_secrets"], "token_ttl": 3600}}`,
+		"/v1/sys/audit":                      `{"data": {"file/": {"type": "file", "options": {"file_path": "/tmp/audit.log"}}}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/pki/root/generate/internal":
+			// Write only endpoints are skipped
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		case "/v1/database/config/mysql":
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	vaultClient, err := api.NewClient(&api.Config{Address: server.URL})
+	require.NoError(t, err)
+
+	config, err := parseExternalConfig([]byte(`{
+		"policies": [
+			{"name": "allow_secrets", "rules": "path \"secret/*\" { capabilities = [\"read\"] }"},
+			{"name": "allow_pki", "rules": "path \"pki/*\" { capabilities = [\"read\"] }"}
+		],
+		"auth": [{
+			"type": "kubernetes",
+			"config": {"kubernetes_host": "https://kubernetes.default.svc", "token_reviewer_jwt": "secret"},
+			"roles": [
+				{"name": "default", "policies": "allow_secrets", "token_ttl": "2h"},
+				{"name": "admin"}
+			]
+		}],
+		"secrets": [
+			{"type": "kv", "path": "secret", "options": {"version": 2}},
+			{
+				"type": "pki",
+				"config": {"default_lease_ttl": "168h", "max_lease_ttl": "360h"},
+				"configuration": {
+					"roles": [{"name": "default", "allowed_domains": "localhost,svc", "ttl": "1m"}],
+					"root/generate": [{"name": "internal", "common_name": "vault"}]
+				}
+			},
+			{"type": "transit"}
+		],
+		"audit": [{"type": "file", "options": {"file_path": "/vault/logs/audit.log"}}]
+	}`))
+	require.NoError(t, err)
+
+	drift, err := detectDrift(vaultClient, config)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		`audit device file has option file_path="/tmp/audit.log" instead of "/vault/logs/audit.log"`,
+		"config of auth method kubernetes differs in kubernetes_host",
+		"policy allow_pki is missing",
+		"policy allow_secrets differs",
+		"role admin of auth method kubernetes is missing",
+		"role default of auth method kubernetes differs in token_ttl",
+		"secret engine pki differs in max_lease_ttl",
+		`secret engine secret has option version="1" instead of "2"`,
+		"secret engine transit is missing",
+	}, drift)
+
+	// The configuration which can't be read fails the drift detection instead of being skipped
+	config.Secrets = append(config.Secrets, externalSecretEngine{
+		Type:          "database",
+		Configuration: map[string][]map[string]interface{}{"config": {{"name": "mysql"}}},
+	})
+	_, err = detectDrift(vaultClient, config)
+	assert.ErrorContains(t, err, "failed to read secret engine configuration database/config/mysql")
+}
@@ -0,0 +1,271 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// The Gateway API types are handled as unstructured objects, so the operator doesn't depend on the Gateway API module
+// and keeps working on clusters where the Gateway API CRDs are not installed.
+var (
+	httpRouteGVK        = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+	tlsRouteGVK         = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Kind: "TLSRoute"}
+	backendTLSPolicyGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1alpha3", Kind: "BackendTLSPolicy"}
+)
+
+// gatewayRoutesForVault creates the Gateway API route of the Vault Service, and the BackendTLSPolicy with the CA
+// if TLS is terminated at the Gateway. The created objects are recorded in the status, only the recorded objects
+// which are no longer needed are removed, so a Vault without a Gateway costs no requests.
+func (r *ReconcileVault) gatewayRoutesForVault(ctx context.Context, v *vaultv1alpha1.Vault, caCertificate []byte) error {
+	objects := gatewayObjectsForVault(v, caCertificate)
+
+	records := make([]string, 0, len(objects))
+	for _, o := range objects {
+		records = append(records, gatewayObjectRecord(o))
+	}
+
+	// Record the objects before creating them, so they are removed even if the reconcile fails halfway
+	if err := r.recordGatewayObjects(ctx, v, slices.Concat(v.Status.GatewayObjects, records)); err != nil {
+		return err
+	}
+
+	for _, o := range objects {
+		if err := controllerutil.SetControllerReference(v, o, r.scheme); err != nil {
+			return err
+		}
+		if err := r.createOrUpdateObject(ctx, o); err != nil {
+			return fmt.Errorf("failed to create/update %s: %v", gatewayObjectRecord(o), err)
+		}
+	}
+
+	// Remove the recorded objects which are no longer needed, e.g. the routes of the other mode
+	for _, record := range v.Status.GatewayObjects {
+		if slices.Contains(records, record) {
+			continue
+		}
+		o := gatewayObjectForRecord(v, record)
+		if o == nil {
+			continue
+		}
+		if err := r.deleteOwnedObject(ctx, v, o); err != nil {
+			return fmt.Errorf("failed to delete %s: %v", record, err)
+		}
+	}
+
+	return r.recordGatewayObjects(ctx, v, records)
+}
+
+// gatewayObjectsForVault returns the Gateway API objects and the CA ConfigMap needed by the Gateway of the Vault
+func gatewayObjectsForVault(v *vaultv1alpha1.Vault, caCertificate []byte) []client.Object {
+	if v.Spec.Gateway == nil {
+		return nil
+	}
+
+	objects := []client.Object{routeForVault(v, v.Name, v.Name, v.GetGatewayHostnames())}
+	if standbyRoute := standbyRouteForVault(v); standbyRoute != nil {
+		objects = append(objects, standbyRoute)
+	}
+
+	// The BackendTLSPolicy is only needed if TLS is terminated at the Gateway
+	if v.Spec.Gateway.IsTLSRoute() || v.Spec.IsTLSDisabled() {
+		return objects
+	}
+	if len(caCertificate) > 0 {
+		objects = append(objects, configMapForGatewayCA(v, caCertificate))
+	}
+	return append(objects, backendTLSPolicyForVault(v, len(caCertificate) > 0))
+}
+
+// gatewayObjectRecord returns the Kind/name an object is recorded with in the status
+func gatewayObjectRecord(o client.Object) string {
+	if _, ok := o.(*corev1.ConfigMap); ok {
+		return "ConfigMap/" + o.GetName()
+	}
+	return o.GetObjectKind().GroupVersionKind().Kind + "/" + o.GetName()
+}
+
+// gatewayObjectForRecord returns the object of a Kind/name recorded in the status, to delete it
+func gatewayObjectForRecord(v *vaultv1alpha1.Vault, record string) client.Object {
+	kind, name, _ := strings.Cut(record, "/")
+	for _, gvk := range []schema.GroupVersionKind{httpRouteGVK, tlsRouteGVK, backendTLSPolicyGVK} {
+		if gvk.Kind == kind {
+			o := &unstructured.Unstructured{}
+			o.SetGroupVersionKind(gvk)
+			o.SetName(name)
+			o.SetNamespace(v.Namespace)
+			return o
+		}
+	}
+	if kind == "ConfigMap" {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: v.Namespace}}
+	}
+	return nil
+}
+
+// recordGatewayObjects updates the Gateway API objects recorded in the status
+func (r *ReconcileVault) recordGatewayObjects(ctx context.Context, v *vaultv1alpha1.Vault, records []string) error {
+	records = slices.Compact(slices.Sorted(slices.Values(records)))
+	if slices.Equal(records, v.Status.GatewayObjects) || (len(records) == 0 && len(v.Status.GatewayObjects) == 0) {
+		return nil
+	}
+	v.Status.GatewayObjects = records
+	if len(records) == 0 {
+		v.Status.GatewayObjects = nil
+	}
+	if err := r.client.Update(ctx, v); err != nil {
+		return fmt.Errorf("failed to update the gateway objects in the vault status: %v", err)
+	}
+	return nil
+}
+
+// standbyRouteForVault returns the route of the standby hostnames, if there are any
+func standbyRouteForVault(v *vaultv1alpha1.Vault) *unstructured.Unstructured {
+	if !v.Spec.ServiceRegistrationEnabled || len(v.Spec.Gateway.StandbyHostnames) == 0 {
+		return nil
+	}
+	return routeForVault(v, v.StandbyServiceName(), v.StandbyServiceName(), v.Spec.Gateway.StandbyHostnames)
+}
+
+func routeForVault(v *vaultv1alpha1.Vault, name, serviceName string, routeHostnames []string) *unstructured.Unstructured {
+	gateway := v.Spec.Gateway
+
+	var parentRefs []interface{}
+	for _, ref := range gateway.ParentRefs {
+		parentRef := map[string]interface{}{
+			"name":      ref.Name,
+			"namespace": v.Namespace,
+		}
+		if ref.Namespace != "" {
+			parentRef["namespace"] = ref.Namespace
+		}
+		if ref.SectionName != "" {
+			parentRef["sectionName"] = ref.SectionName
+		}
+		if ref.Port != nil {
+			parentRef["port"] = int64(*ref.Port)
+		}
+		parentRefs = append(parentRefs, parentRef)
+	}
+
+	spec := map[string]interface{}{
+		"parentRefs": parentRefs,
+		"rules": []interface{}{
+			map[string]interface{}{
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"name": serviceName,
+						"port": int64(v.Spec.GetAPIPort()),
+					},
+				},
+			},
+		},
+	}
+
+	var hostnames []interface{}
+	for _, hostname := range routeHostnames {
+		hostnames = append(hostnames, hostname)
+	}
+	if len(hostnames) > 0 {
+		spec["hostnames"] = hostnames
+	}
+
+	route := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	if gateway.IsTLSRoute() {
+		route.SetGroupVersionKind(tlsRouteGVK)
+	} else {
+		route.SetGroupVersionKind(httpRouteGVK)
+	}
+	route.SetName(name)
+	route.SetNamespace(v.Namespace)
+	route.SetLabels(v.LabelsForVault())
+	route.SetAnnotations(gateway.Annotations)
+
+	return route
+}
+
+func gatewayCAConfigMapName(v *vaultv1alpha1.Vault) string {
+	return v.Name + "-gateway-ca"
+}
+
+func configMapForGatewayCA(v *vaultv1alpha1.Vault, caCertificate []byte) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      gatewayCAConfigMapName(v),
+			Namespace: v.Namespace,
+			Labels:    v.LabelsForVault(),
+		},
+		Data: map[string]string{"ca.crt": string(caCertificate)},
+	}
+}
+
+// backendTLSPolicyForVault makes the Gateway verify the Vault certificate, with the Vault CA if there is one
+// or with the system CAs if the certificate comes from an existing Secret without a CA
+func backendTLSPolicyForVault(v *vaultv1alpha1.Vault, withCA bool) *unstructured.Unstructured {
+	validation := map[string]interface{}{
+		"hostname": serviceFQDN(v.Name, v.Namespace),
+	}
+	if withCA {
+		validation["caCertificateRefs"] = []interface{}{
+			map[string]interface{}{
+				"group": "",
+				"kind":  "ConfigMap",
+				"name":  gatewayCAConfigMapName(v),
+			},
+		}
+	} else {
+		validation["wellKnownCACertificates"] = "System"
+	}
+
+	// The standby instances present the same certificate, so they are validated with the same hostname
+	targetRefs := []interface{}{
+		map[string]interface{}{
+			"group": "",
+			"kind":  "Service",
+			"name":  v.Name,
+		},
+	}
+	if standbyRouteForVault(v) != nil {
+		targetRefs = append(targetRefs, map[string]interface{}{
+			"group": "",
+			"kind":  "Service",
+			"name":  v.StandbyServiceName(),
+		})
+	}
+
+	policy := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"targetRefs": targetRefs,
+			"validation": validation,
+		},
+	}}
+	policy.SetGroupVersionKind(backendTLSPolicyGVK)
+	policy.SetName(v.Name)
+	policy.SetNamespace(v.Namespace)
+	policy.SetLabels(v.LabelsForVault())
+
+	return policy
+}
@@ -0,0 +1,109 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestRouteForVault(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"},
+		Spec: vaultv1alpha1.VaultSpec{
+			TLSAdditionalHosts: []string{"vault.example.com", "10.0.0.1"},
+			Gateway: &vaultv1alpha1.Gateway{
+				ParentRefs: []vaultv1alpha1.GatewayParentReference{{Name: "gateway", Namespace: "gateway", SectionName: "https"}},
+			},
+		},
+	}
+
+	route := routeForVault(v, v.Name, v.Name, v.GetGatewayHostnames())
+	assert.Equal(t, httpRouteGVK, route.GroupVersionKind())
+	hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+	assert.Equal(t, []string{"vault.example.com"}, hostnames)
+	parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "gateway", "namespace": "gateway", "sectionName": "https"}}, parentRefs)
+
+	// The route hostnames end up in the Vault certificate only if TLS is passed through
+	service := &corev1.Service{}
+	assert.NotContains(t, hostsAndIPsForVault(v, service), "vault.internal.example.com")
+
+	v.Spec.Gateway.Mode = vaultv1alpha1.GatewayModeTLSRoute
+	v.Spec.Gateway.Hostnames = []string{"vault.internal.example.com"}
+	route = routeForVault(v, v.Name, v.Name, v.GetGatewayHostnames())
+	assert.Equal(t, tlsRouteGVK, route.GroupVersionKind())
+	assert.Contains(t, hostsAndIPsForVault(v, service), "vault.internal.example.com")
+
+	policy := backendTLSPolicyForVault(v, true)
+	hostname, _, _ := unstructured.NestedString(policy.Object, "spec", "validation", "hostname")
+	assert.Equal(t, "vault.vault.svc.cluster.local", hostname)
+}
+
+func TestGatewayRoutesForVaultCleanup(t *testing.T) {
+	ctx := context.Background()
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault", UID: "uid"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Gateway: &vaultv1alpha1.Gateway{ParentRefs: []vaultv1alpha1.GatewayParentReference{{Name: "gateway"}}},
+		},
+	}
+	r, c := newTestReconciler(t, v)
+
+	require.NoError(t, r.gatewayRoutesForVault(ctx, v, []byte("ca")))
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(httpRouteGVK)
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "vault", Name: "vault"}, route))
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "vault", Name: "vault-gateway-ca"}, &corev1.ConfigMap{}))
+	assert.Equal(t, []string{"BackendTLSPolicy/vault", "ConfigMap/vault-gateway-ca", "HTTPRoute/vault"}, v.Status.GatewayObjects)
+
+	// Passing TLS through to Vault replaces the HTTPRoute and removes the backend TLS objects
+	v.Spec.Gateway.Mode = vaultv1alpha1.GatewayModeTLSRoute
+	require.NoError(t, r.gatewayRoutesForVault(ctx, v, []byte("ca")))
+	err := c.Get(ctx, client.ObjectKey{Namespace: "vault", Name: "vault"}, route)
+	assert.True(t, apierrors.IsNotFound(err))
+	err = c.Get(ctx, client.ObjectKey{Namespace: "vault", Name: "vault-gateway-ca"}, &corev1.ConfigMap{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	// A ConfigMap of someone else with the same name is left alone
+	foreign := configMapForGatewayCA(v, []byte("other"))
+	require.NoError(t, c.Create(ctx, foreign))
+
+	v.Spec.Gateway = nil
+	require.NoError(t, r.gatewayRoutesForVault(ctx, v, []byte("ca")))
+	route.SetGroupVersionKind(tlsRouteGVK)
+	err = c.Get(ctx, client.ObjectKey{Namespace: "vault", Name: "vault"}, route)
+	assert.True(t, apierrors.IsNotFound(err))
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(foreign), foreign))
+	assert.False(t, controllerutil.HasControllerReference(foreign))
+
+	stored := &vaultv1alpha1.Vault{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(v), stored))
+	assert.Empty(t, stored.Status.GatewayObjects)
+
+	// Without recorded objects nothing is looked up, the Gateway API CRDs may be missing
+	r.client = nil
+	require.NoError(t, r.gatewayRoutesForVault(ctx, v, []byte("ca")))
+}
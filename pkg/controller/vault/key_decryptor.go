@@ -0,0 +1,211 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// keyDecryptorInterval is how often the key decryptor sidecar refreshes the decrypted keys,
+// so rekeys and root token rotations reach the unsealer and the configurer
+const keyDecryptorInterval = 10 * time.Second
+
+// RunKeyDecryptor keeps the keys and the root token of the encrypted unseal keys Secret of the Vault decrypted
+// in the directory, where the unsealer and the configurer read them in file mode, until the context is done
+func RunKeyDecryptor(ctx context.Context, c client.Client, vaultKey client.ObjectKey, dir string, rootTokenOnly bool) {
+	for {
+		if err := decryptKeys(ctx, c, vaultKey, dir, rootTokenOnly); err != nil {
+			log.Error(err, "failed to decrypt the unseal keys", "vault", vaultKey)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(keyDecryptorInterval):
+		}
+	}
+}
+
+// decryptKeys writes the decrypted keys of the unseal keys Secret into the directory,
+// and removes the keys which are not stored anymore
+func decryptKeys(ctx context.Context, c client.Client, vaultKey client.ObjectKey, dir string, rootTokenOnly bool) error {
+	v := &vaultv1alpha1.Vault{}
+	if err := c.Get(ctx, vaultKey, v); err != nil {
+		return fmt.Errorf("failed to get vault: %v", err)
+	}
+
+	kek, err := keyEncryptionKeyForVault(ctx, c, v)
+	if err != nil {
+		return err
+	}
+
+	// The operator creates the Secret when it initialises Vault
+	secretNamespace, secretName := v.Spec.UnsealConfig.KubernetesSecret(v)
+	secret := corev1.Secret{}
+	err = c.Get(ctx, client.ObjectKey{Namespace: secretNamespace, Name: secretName}, &secret)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get unseal keys secret: %v", err)
+	}
+
+	for key, value := range secret.Data {
+		if !isDecryptedKey(key, rootTokenOnly) {
+			continue
+		}
+
+		// Values stored before the encryption was enabled are encrypted by the operator soon
+		if _, ok := parseEnvelope(value); ok && kek != nil {
+			if value, err = openValue(ctx, kek, value); err != nil {
+				return fmt.Errorf("failed to decrypt %s: %v", key, err)
+			}
+		}
+
+		if err := writeDecryptedKey(filepath.Join(dir, key), value); err != nil {
+			return fmt.Errorf("failed to write %s: %v", key, err)
+		}
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if _, ok := secret.Data[file.Name()]; !ok && isDecryptedKey(file.Name(), rootTokenOnly) {
+			if err := os.Remove(filepath.Join(dir, file.Name())); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// isDecryptedKey returns true if the key of the unseal keys Secret is kept decrypted by the key decryptor,
+// the new keys staged during a rekey are not in use yet
+func isDecryptedKey(key string, rootTokenOnly bool) bool {
+	if key == rootTokenKey {
+		return true
+	}
+	if rootTokenOnly {
+		return false
+	}
+	for _, prefix := range []string{unsealKeyPrefix, recoveryKeyPrefix} {
+		if strings.HasPrefix(key, prefix) && !strings.HasPrefix(key, prefix+"staged-") {
+			return true
+		}
+	}
+	return false
+}
+
+// writeDecryptedKey replaces the file with the value unless it holds the value already
+func writeDecryptedKey(path string, value []byte) error {
+	if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, value) {
+		return nil
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, value, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// withKeyDecryptorContainer adds the sidecar decrypting the unseal keys for the bank-vaults container next to it,
+// the configurer only gets the root token
+func withKeyDecryptorContainer(v *vaultv1alpha1.Vault, rootTokenOnly bool, containers []corev1.Container) []corev1.Container {
+	if !v.Spec.UnsealConfig.IsEncrypted() {
+		return containers
+	}
+
+	command := []string{
+		"vault-operator", "decrypt-keys",
+		"--vault-namespace", v.Namespace,
+		"--vault-name", v.Name,
+		"--keys-dir", vaultv1alpha1.DecryptedKeysMountPath,
+	}
+	if rootTokenOnly {
+		command = append(command, "--root-token-only")
+	}
+
+	volumeMounts := []corev1.VolumeMount{{
+		Name:      "vault-decrypted-keys",
+		MountPath: vaultv1alpha1.DecryptedKeysMountPath,
+	}}
+	if socket := v.Spec.UnsealConfig.Kubernetes.Encryption.KMSPluginSocket; socket != "" {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "vault-kms-plugin",
+			MountPath: socket,
+		})
+	}
+
+	return append(containers, corev1.Container{
+		Image:           OperatorImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Name:            "key-decryptor",
+		Command:         command,
+		VolumeMounts:    volumeMounts,
+		Resources:       getBankVaultsResource(v),
+	})
+}
+
+// withDecryptedKeysVolume adds the in-memory volume of the decrypted keys, and the socket of the KMS plugin
+func withDecryptedKeysVolume(v *vaultv1alpha1.Vault, volumes []corev1.Volume) []corev1.Volume {
+	if !v.Spec.UnsealConfig.IsEncrypted() {
+		return volumes
+	}
+
+	volumes = append(volumes, corev1.Volume{
+		Name: "vault-decrypted-keys",
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory},
+		},
+	})
+	if socket := v.Spec.UnsealConfig.Kubernetes.Encryption.KMSPluginSocket; socket != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: "vault-kms-plugin",
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: socket,
+					Type: ptr.To(corev1.HostPathSocket),
+				},
+			},
+		})
+	}
+	return volumes
+}
+
+// withDecryptedKeysVolumeMount mounts the decrypted keys into the bank-vaults container, which runs in file mode
+func withDecryptedKeysVolumeMount(v *vaultv1alpha1.Vault, volumeMounts []corev1.VolumeMount) []corev1.VolumeMount {
+	if v.Spec.UnsealConfig.IsEncrypted() {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "vault-decrypted-keys",
+			MountPath: vaultv1alpha1.DecryptedKeysMountPath,
+		})
+	}
+	return volumeMounts
+}
@@ -0,0 +1,387 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const rootTokenKey = "vault-root"
+
+// rotateKeys rekeys Vault and regenerates the root token when they are due, and returns the resulting condition
+// and the time until the next periodic rotation, zero if there is none
+func (r *ReconcileVault) rotateKeys(ctx context.Context, v *vaultv1alpha1.Vault, leader string) (corev1.ComponentCondition, time.Duration) {
+	condition := corev1.ComponentCondition{
+		Type:   vaultv1alpha1.KeysRotatedCondition,
+		Status: corev1.ConditionTrue,
+	}
+	if current := v.Status.GetCondition(vaultv1alpha1.KeysRotatedCondition); current != nil {
+		condition = *current
+	}
+
+	// The custodians hold the key shares, the operator can neither rekey with them nor regenerate the root token
+	if v.Spec.UnsealConfig.OperatorUnseals() {
+		condition.Status = corev1.ConditionFalse
+		condition.Message = ""
+		condition.Error = "the unseal keys are held by the custodians, the operator can't rotate them"
+		return condition, 0
+	}
+
+	rotation := v.Spec.UnsealConfig.Options.Rotation
+	if v.Status.KeyRotation == nil {
+		v.Status.KeyRotation = &vaultv1alpha1.KeyRotationStatus{}
+	}
+	status := v.Status.KeyRotation
+
+	// The first check only starts the periodic rotations
+	now := metav1.Now()
+	if status.LastRekeyTime == nil {
+		status.LastRekeyTime = &now
+	}
+	if status.LastRootTokenTime == nil {
+		status.LastRootTokenTime = &now
+	}
+
+	err := func() error {
+		vaultClient, err := r.httpClients.vaultClientForPod(ctx, r.client, v, leader)
+		if err != nil {
+			return err
+		}
+
+		sealStatus, err := vaultClient.Sys().SealStatusWithContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get seal status: %v", err)
+		}
+
+		shares, threshold := keyShares(v, sealStatus)
+		rekeyDue := sealStatus.N != shares || sealStatus.T != threshold ||
+			isDue(status.LastRekeyTime, rotation.GetRekeyInterval())
+		rootTokenDue := rotation.RootTokenGeneration != status.RootTokenGeneration ||
+			isDue(status.LastRootTokenTime, rotation.GetRootTokenInterval())
+		if !rekeyDue && !rootTokenDue {
+			return nil
+		}
+
+		if !v.Spec.UnsealConfig.IsKubernetes() {
+			return fmt.Errorf("the operator needs the keys in a Kubernetes Secret to rotate them")
+		}
+		secretNamespace, secretName := v.Spec.UnsealConfig.KubernetesSecret(v)
+		secretKey := client.ObjectKey{Namespace: secretNamespace, Name: secretName}
+
+		// The recovery keys of an auto seal may be kept apart from the root token
+		keysKey := secretKey
+		if sealStatus.RecoverySeal {
+			keysNamespace, keysName := v.Spec.UnsealConfig.RecoveryKeysSecret(v)
+			keysKey = client.ObjectKey{Namespace: keysNamespace, Name: keysName}
+		}
+
+		if rekeyDue {
+			if err := r.rekeyVault(ctx, v, vaultClient, keysKey, sealStatus); err != nil {
+				return err
+			}
+			status.LastRekeyTime = &now
+		}
+
+		if rootTokenDue {
+			if err := r.regenerateRootToken(ctx, v, vaultClient, secretKey, keysKey, sealStatus.Type); err != nil {
+				return err
+			}
+			status.LastRootTokenTime = &now
+			status.RootTokenGeneration = rotation.RootTokenGeneration
+		}
+
+		condition.Status = corev1.ConditionTrue
+		condition.Error = ""
+		condition.Message = ""
+		return nil
+	}()
+	if err != nil {
+		condition.Status = corev1.ConditionFalse
+		condition.Error = err.Error()
+		r.recorder.Event(v, corev1.EventTypeWarning, "KeyRotationFailed", err.Error())
+	}
+
+	var next time.Duration
+	for _, due := range []time.Duration{
+		untilDue(status.LastRekeyTime, rotation.GetRekeyInterval()),
+		untilDue(status.LastRootTokenTime, rotation.GetRootTokenInterval()),
+	} {
+		if due > 0 && (next == 0 || due < next) {
+			next = due
+		}
+	}
+
+	return condition, next
+}
+
+// isDue returns true if a periodic rotation last done at the given time is due
+func isDue(last *metav1.Time, interval time.Duration) bool {
+	return interval > 0 && time.Since(last.Time) >= interval
+}
+
+// untilDue returns the time until a periodic rotation is due, zero if it is not periodic
+func untilDue(last *metav1.Time, interval time.Duration) time.Duration {
+	if interval == 0 {
+		return 0
+	}
+	return max(time.Until(last.Add(interval)), time.Second)
+}
+
+// keyShares returns the configured shares and threshold of the unseal keys, or of the recovery keys of an auto seal
+func keyShares(v *vaultv1alpha1.Vault, sealStatus *api.SealStatusResponse) (int, int) {
+	options := v.Spec.UnsealConfig.Options
+	if sealStatus.RecoverySeal {
+		return options.GetRecoveryShares(), options.GetRecoveryThreshold()
+	}
+	return options.GetSecretShares(), options.GetSecretThreshold()
+}
+
+// rekeyVault replaces the unseal keys, or the recovery keys of an auto seal, with a new set of keys
+// matching their configured shares and threshold, and stores them in place of the previous keys.
+// Vault keeps the previous keys until the new ones, already staged in the secret, are verified.
+func (r *ReconcileVault) rekeyVault(ctx context.Context, v *vaultv1alpha1.Vault, vaultClient *api.Client, secretKey client.ObjectKey, sealStatus *api.SealStatusResponse) error {
+	secret := corev1.Secret{}
+	if err := r.nonNamespacedClient.Get(ctx, secretKey, &secret); err != nil {
+		return fmt.Errorf("failed to get unseal keys secret: %v", err)
+	}
+
+	prefix := keyPrefix(sealStatus.Type)
+	keys, err := r.openKeys(ctx, v, storedKeys(&secret, prefix))
+	if err != nil {
+		return err
+	}
+	if len(keys) < sealStatus.T {
+		return fmt.Errorf("only %d of the %d keys needed to rekey are stored in secret %s", len(keys), sealStatus.T, secretKey)
+	}
+
+	sys := vaultClient.Sys()
+	cancel, init, update, verify := sys.RekeyCancelWithContext, sys.RekeyInitWithContext, sys.RekeyUpdateWithContext, sys.RekeyVerificationUpdateWithContext
+	if prefix == recoveryKeyPrefix {
+		cancel, init, update, verify = sys.RekeyRecoveryKeyCancelWithContext, sys.RekeyRecoveryKeyInitWithContext, sys.RekeyRecoveryKeyUpdateWithContext, sys.RekeyRecoveryKeyVerificationUpdateWithContext
+	}
+
+	// A rekey interrupted by a previous reconcile would keep its nonce, start over
+	if err := cancel(ctx); err != nil {
+		return fmt.Errorf("failed to cancel previous rekey: %v", err)
+	}
+
+	shares, threshold := keyShares(v, sealStatus)
+	rekeyStatus, err := init(ctx, &api.RekeyInitRequest{
+		SecretShares:        shares,
+		SecretThreshold:     threshold,
+		RequireVerification: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start rekey: %v", err)
+	}
+
+	var result *api.RekeyUpdateResponse
+	for _, key := range keys[:sealStatus.T] {
+		result, err = update(ctx, key, rekeyStatus.Nonce)
+		if err != nil {
+			return fmt.Errorf("failed to submit key for rekey: %v", err)
+		}
+	}
+	if result == nil || !result.Complete || !result.VerificationRequired {
+		return fmt.Errorf("rekey is not pending verification after submitting %d keys", sealStatus.T)
+	}
+
+	newKeys, err := r.sealKeys(ctx, v, result.Keys)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt the new keys after rekey: %v", err)
+	}
+
+	// Stage the new keys next to the previous ones, which keep working until the verification
+	stagedPrefix := prefix + "staged-"
+	err = r.updateStoredKeys(ctx, secretKey, stagedPrefix, stagedPrefix, newKeys)
+	if err != nil {
+		return fmt.Errorf("failed to stage the new keys after rekey: %v", err)
+	}
+
+	var verification *api.RekeyVerificationUpdateResponse
+	for _, key := range result.Keys {
+		verification, err = verify(ctx, key, result.VerificationNonce)
+		if err != nil {
+			return fmt.Errorf("failed to submit key for rekey verification: %v", err)
+		}
+		if verification.Complete {
+			break
+		}
+	}
+	if verification == nil || !verification.Complete {
+		return fmt.Errorf("rekey verification is not complete after submitting %d keys", len(result.Keys))
+	}
+
+	// The previous keys are useless from now on, the new ones stay staged if they can't replace them
+	err = r.updateStoredKeys(ctx, secretKey, prefix, prefix, newKeys)
+	if err != nil {
+		return fmt.Errorf("failed to store the new keys after rekey, they are staged under %s in secret %s: %v", stagedPrefix, secretKey, err)
+	}
+
+	r.recorder.Eventf(v, corev1.EventTypeNormal, "Rekeyed", "Rekeyed Vault with %d shares and a threshold of %d", shares, threshold)
+	return nil
+}
+
+// updateStoredKeys removes the keys of the secret starting with the given prefix and stores the keys under
+// the index prefix, retrying hard to not lose them
+func (r *ReconcileVault) updateStoredKeys(ctx context.Context, secretKey client.ObjectKey, prefix, indexPrefix string, keys [][]byte) error {
+	return retry.OnError(retry.DefaultBackoff, func(error) bool { return true }, func() error {
+		secret := corev1.Secret{}
+		if err := r.nonNamespacedClient.Get(ctx, secretKey, &secret); err != nil {
+			return err
+		}
+		for key := range secret.Data {
+			if strings.HasPrefix(key, prefix) {
+				delete(secret.Data, key)
+			}
+		}
+		for i, key := range keys {
+			secret.Data[fmt.Sprint(indexPrefix, i)] = key
+		}
+		return r.nonNamespacedClient.Update(ctx, &secret)
+	})
+}
+
+// regenerateRootToken generates a new root token with the stored keys, stores it and revokes the previous one
+func (r *ReconcileVault) regenerateRootToken(ctx context.Context, v *vaultv1alpha1.Vault, vaultClient *api.Client, secretKey, keysKey client.ObjectKey, sealType string) error {
+	secret := corev1.Secret{}
+	if err := r.nonNamespacedClient.Get(ctx, secretKey, &secret); err != nil {
+		return fmt.Errorf("failed to get unseal keys secret: %v", err)
+	}
+
+	// The root token is revoked once the operator identity is bootstrapped, unless it should be stored
+	previousToken, ok := secret.Data[rootTokenKey]
+	if !ok && v.Status.OperatorIdentityHash == "" {
+		return fmt.Errorf("root token is missing from secret %s, it can't be revoked", secretKey)
+	}
+
+	keysSecret := secret
+	if keysKey != secretKey {
+		if err := r.nonNamespacedClient.Get(ctx, keysKey, &keysSecret); err != nil {
+			return fmt.Errorf("failed to get recovery keys secret: %v", err)
+		}
+	}
+	keys := storedKeys(&keysSecret, keyPrefix(sealType))
+	if ok {
+		keys = append([]string{string(previousToken)}, keys...)
+	}
+	keys, err := r.openKeys(ctx, v, keys)
+	if err != nil {
+		return err
+	}
+	if ok {
+		previousToken, keys = []byte(keys[0]), keys[1:]
+	}
+
+	sys := vaultClient.Sys()
+	if err := sys.GenerateRootCancelWithContext(ctx); err != nil {
+		return fmt.Errorf("failed to cancel previous root token generation: %v", err)
+	}
+
+	// Vault generates the one-time password which encodes the new token
+	generateStatus, err := sys.GenerateRootInitWithContext(ctx, "", "")
+	if err != nil {
+		return fmt.Errorf("failed to start root token generation: %v", err)
+	}
+	otp := generateStatus.OTP
+
+	for _, key := range keys {
+		generateStatus, err = sys.GenerateRootUpdateWithContext(ctx, key, generateStatus.Nonce)
+		if err != nil {
+			return fmt.Errorf("failed to submit key for root token generation: %v", err)
+		}
+		if generateStatus.Complete {
+			break
+		}
+	}
+	if !generateStatus.Complete {
+		return fmt.Errorf("root token generation is not complete after submitting %d keys", len(keys))
+	}
+
+	token, err := decodeRootToken(generateStatus.EncodedToken, otp)
+	if err != nil {
+		return err
+	}
+
+	// The operator identity is used in place of the root token, the new one only proves the keys still generate one
+	if storeRootToken := v.Spec.UnsealConfig.Options.StoreRootToken; !ok && storeRootToken != nil && !*storeRootToken {
+		if err := revokeRootToken(ctx, vaultClient, token); err != nil {
+			return err
+		}
+		r.recorder.Event(v, corev1.EventTypeNormal, "RootTokenRegenerated", "Regenerated a root token and revoked it, the operator uses its own identity")
+		return nil
+	}
+	storedToken, err := r.sealKeys(ctx, v, []string{token})
+	if err != nil {
+		return fmt.Errorf("failed to encrypt the new root token: %v", err)
+	}
+
+	err = retry.OnError(retry.DefaultBackoff, func(error) bool { return true }, func() error {
+		secret := corev1.Secret{}
+		if err := r.nonNamespacedClient.Get(ctx, secretKey, &secret); err != nil {
+			return err
+		}
+		secret.Data[rootTokenKey] = storedToken[0]
+		return r.nonNamespacedClient.Update(ctx, &secret)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store the new root token: %v", err)
+	}
+
+	if !ok {
+		r.recorder.Event(v, corev1.EventTypeNormal, "RootTokenRegenerated", "Regenerated the root token")
+		return nil
+	}
+
+	// Tokens created by the previous root token keep working
+	vaultClient, err = vaultClient.Clone()
+	if err != nil {
+		return err
+	}
+	vaultClient.SetToken(token)
+	if err := vaultClient.Auth().Token().RevokeOrphanWithContext(ctx, string(previousToken)); err != nil {
+		return fmt.Errorf("failed to revoke the previous root token: %v", err)
+	}
+
+	r.recorder.Event(v, corev1.EventTypeNormal, "RootTokenRegenerated", "Regenerated the root token and revoked the previous one")
+	return nil
+}
+
+// decodeRootToken decodes the token returned by a root token generation with the one-time password
+func decodeRootToken(encodedToken, otp string) (string, error) {
+	tokenBytes, err := base64.RawStdEncoding.DecodeString(encodedToken)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode root token: %v", err)
+	}
+	if len(tokenBytes) != len(otp) {
+		return "", fmt.Errorf("failed to decode root token: length of the one-time password doesn't match")
+	}
+
+	for i := range tokenBytes {
+		tokenBytes[i] ^= otp[i]
+	}
+	return string(tokenBytes), nil
+}
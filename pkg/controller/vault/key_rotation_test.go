@@ -0,0 +1,167 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestKeyRotation(t *testing.T) {
+	otp := "0123456789abcdefghijklmnopqr"
+	newToken := "hvs.RotatedRootTokenValue000"
+	encoded := []byte(newToken)
+	for i := range encoded {
+		encoded[i] ^= otp[i]
+	}
+
+	var requests []string
+	var verified int
+	verifyFails := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "PUT /v1/sys/rekey/init":
+			_, _ = w.Write([]byte(`{"nonce": "rekey", "started": true}`))
+		case "PUT /v1/sys/rekey/update":
+			_, _ = w.Write([]byte(`{"nonce": "rekey", "complete": true, "keys": ["new-0", "new-1", "new-2"], "verification_required": true, "verification_nonce": "verify"}`))
+		case "PUT /v1/sys/rekey/verify":
+			if verifyFails {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			verified++
+			_, _ = w.Write([]byte(`{"nonce": "verify", "complete": ` + strconv.FormatBool(verified == 2) + `}`))
+		case "PUT /v1/sys/generate-root/attempt":
+			_, _ = w.Write([]byte(`{"nonce": "root", "started": true, "otp": "` + otp + `", "otp_length": 28}`))
+		case "PUT /v1/sys/generate-root/update":
+			_, _ = w.Write([]byte(`{"nonce": "root", "complete": true, "encoded_token": "` + base64.RawStdEncoding.EncodeToString(encoded) + `"}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	vaultClient, err := api.NewClient(&api.Config{Address: server.URL})
+	require.NoError(t, err)
+
+	v := &vaultv1alpha1.Vault{ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"}}
+	v.Spec.UnsealConfig.Options.SecretShares = ptr.To[uint](3)
+	v.Spec.UnsealConfig.Options.SecretThreshold = ptr.To[uint](2)
+	keys := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-unseal-keys", Namespace: "vault"},
+		Data: map[string][]byte{
+			"vault-unseal-0": []byte("key-0"),
+			"vault-unseal-1": []byte("key-1"),
+			"vault-unseal-2": []byte("key-2"),
+			"vault-unseal-3": []byte("key-3"),
+			"vault-unseal-4": []byte("key-4"),
+			"vault-root":     []byte("hvs.previous"),
+		},
+	}
+
+	r, c := newTestReconciler(t, keys)
+	secretKey := client.ObjectKeyFromObject(keys)
+
+	sealStatus := &api.SealStatusResponse{Type: vaultv1alpha1.SealTypeShamir, T: 3, N: 5}
+
+	// The previous keys are kept until Vault verified the new ones
+	verifyFails = true
+	require.Error(t, r.rekeyVault(context.Background(), v, vaultClient, secretKey, sealStatus))
+	staged := corev1.Secret{}
+	require.NoError(t, c.Get(context.Background(), secretKey, &staged))
+	assert.Equal(t, []byte("key-0"), staged.Data["vault-unseal-0"])
+	assert.Equal(t, []byte("new-0"), staged.Data["vault-unseal-staged-0"])
+
+	verifyFails = false
+	requests = nil
+	require.NoError(t, r.rekeyVault(context.Background(), v, vaultClient, secretKey, sealStatus))
+	require.NoError(t, r.regenerateRootToken(context.Background(), v, vaultClient, secretKey, secretKey, sealStatus.Type))
+
+	rotated := corev1.Secret{}
+	require.NoError(t, c.Get(context.Background(), secretKey, &rotated))
+	assert.Equal(t, map[string][]byte{
+		"vault-unseal-0": []byte("new-0"),
+		"vault-unseal-1": []byte("new-1"),
+		"vault-unseal-2": []byte("new-2"),
+		"vault-root":     []byte(newToken),
+	}, rotated.Data)
+
+	// Only the threshold of the previous and new keys is submitted, the previous root token is revoked
+	assert.Equal(t, []string{
+		"DELETE /v1/sys/rekey/init",
+		"PUT /v1/sys/rekey/init",
+		"PUT /v1/sys/rekey/update",
+		"PUT /v1/sys/rekey/update",
+		"PUT /v1/sys/rekey/update",
+		"PUT /v1/sys/rekey/verify",
+		"PUT /v1/sys/rekey/verify",
+		"DELETE /v1/sys/generate-root/attempt",
+		"PUT /v1/sys/generate-root/attempt",
+		"PUT /v1/sys/generate-root/update",
+		"PUT /v1/auth/token/revoke-orphan",
+	}, requests)
+
+	// Without a stored root token the operator identity is used, the new root token is revoked unless it should be stored
+	delete(rotated.Data, rootTokenKey)
+	require.NoError(t, c.Update(context.Background(), &rotated))
+	require.Error(t, r.regenerateRootToken(context.Background(), v, vaultClient, secretKey, secretKey, sealStatus.Type))
+
+	v.Status.OperatorIdentityHash = "identity"
+	v.Spec.UnsealConfig.Options.StoreRootToken = ptr.To(false)
+	requests = nil
+	require.NoError(t, r.regenerateRootToken(context.Background(), v, vaultClient, secretKey, secretKey, sealStatus.Type))
+	require.NoError(t, c.Get(context.Background(), secretKey, &rotated))
+	assert.NotContains(t, rotated.Data, rootTokenKey)
+	assert.Equal(t, []string{
+		"DELETE /v1/sys/generate-root/attempt",
+		"PUT /v1/sys/generate-root/attempt",
+		"PUT /v1/sys/generate-root/update",
+		"PUT /v1/auth/token/revoke-self",
+	}, requests)
+
+	v.Spec.UnsealConfig.Options.StoreRootToken = nil
+	require.NoError(t, r.regenerateRootToken(context.Background(), v, vaultClient, secretKey, secretKey, sealStatus.Type))
+	require.NoError(t, c.Get(context.Background(), secretKey, &rotated))
+	assert.Equal(t, []byte(newToken), rotated.Data[rootTokenKey])
+}
+
+func TestKeyRotationWithCustodians(t *testing.T) {
+	v := &vaultv1alpha1.Vault{ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"}}
+	v.Spec.UnsealConfig.Custodians = &vaultv1alpha1.CustodianUnsealConfig{}
+	v.Spec.UnsealConfig.Options.Rotation = &vaultv1alpha1.KeyRotation{RootTokenGeneration: "1"}
+	r, _ := newTestReconciler(t, v)
+
+	// The shares of the custodians are never asked from the operator's Secret
+	condition, next := r.rotateKeys(context.Background(), v, "vault-0")
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Contains(t, condition.Error, "custodians")
+	assert.Zero(t, next)
+	assert.Nil(t, v.Status.KeyRotation)
+}
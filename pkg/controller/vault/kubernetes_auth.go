@@ -0,0 +1,304 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// kubernetesAuthForVault returns the Kubernetes auth method of KubernetesAuth, configured with the host,
+// the CA and the issuer of the in-cluster config of the operator, and the SHA256 hash of it
+func kubernetesAuthForVault(v *vaultv1alpha1.Vault, config *rest.Config) (*externalAuth, string, error) {
+	spec := v.Spec.KubernetesAuth
+	authConfig, err := kubernetesAuthConfig(config, spec.Host, spec.Issuer)
+	if err != nil {
+		return nil, "", err
+	}
+
+	roles := make([]map[string]interface{}, 0, len(spec.Roles))
+	for _, role := range spec.Roles {
+		data := map[string]interface{}{
+			"name":                             role.Name,
+			"bound_service_account_names":      role.BoundServiceAccountNames,
+			"bound_service_account_namespaces": role.BoundServiceAccountNamespaces,
+		}
+		if len(role.Policies) != 0 {
+			data["token_policies"] = role.Policies
+		}
+		if role.TTL != "" {
+			data["token_ttl"] = role.TTL
+		}
+		if role.Audience != "" {
+			data["audience"] = role.Audience
+		}
+		roles = append(roles, data)
+	}
+
+	auth := &externalAuth{
+		Type:   "kubernetes",
+		Path:   spec.GetPath(),
+		Config: authConfig,
+		Roles:  roles,
+	}
+
+	data, err := json.Marshal(auth)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return auth, fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// kubernetesAuthConfig returns the config of a Kubernetes auth method with the host, the CA and the issuer
+// of the in-cluster config of the operator, unless the host or the issuer are given
+func kubernetesAuthConfig(config *rest.Config, host, issuer string) (map[string]interface{}, error) {
+	if config == nil {
+		return nil, fmt.Errorf("the operator has no Kubernetes client config to configure the Kubernetes auth method with")
+	}
+
+	if host == "" {
+		host = config.Host
+	}
+
+	caCertificate := config.CAData
+	if len(caCertificate) == 0 && config.CAFile != "" {
+		var err error
+		if caCertificate, err = os.ReadFile(config.CAFile); err != nil {
+			return nil, fmt.Errorf("failed to read Kubernetes CA certificate: %v", err)
+		}
+	}
+
+	if issuer == "" {
+		claims, err := tokenClaims(config)
+		if err != nil {
+			return nil, err
+		}
+		issuer = claims.Issuer
+	}
+
+	// Vault reviews the tokens with the token of its own ServiceAccount when no reviewer JWT is set
+	authConfig := map[string]interface{}{
+		"kubernetes_host": host,
+	}
+	if len(caCertificate) != 0 {
+		authConfig["kubernetes_ca_cert"] = string(caCertificate)
+	}
+	if issuer != "" {
+		authConfig["issuer"] = issuer
+	}
+
+	return authConfig, nil
+}
+
+// serviceAccountClaims are the claims of a ServiceAccount token used by the operator
+type serviceAccountClaims struct {
+	Issuer string `json:"iss"`
+	// Subject is system:serviceaccount:<namespace>:<name>
+	Subject string `json:"sub"`
+}
+
+// tokenClaims returns the claims of the ServiceAccount token of the operator, or nothing if it doesn't use one
+func tokenClaims(config *rest.Config) (serviceAccountClaims, error) {
+	var claims serviceAccountClaims
+
+	token := config.BearerToken
+	if token == "" && config.BearerTokenFile != "" {
+		data, err := os.ReadFile(config.BearerTokenFile)
+		if err != nil {
+			return claims, fmt.Errorf("failed to read ServiceAccount token: %v", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims, nil
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return claims, fmt.Errorf("failed to decode ServiceAccount token: %v", err)
+	}
+
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return claims, fmt.Errorf("failed to parse ServiceAccount token: %v", err)
+	}
+
+	return claims, nil
+}
+
+// configureKubernetesAuth applies the KubernetesAuth to the leader unless it is applied already,
+// and returns the resulting condition and the hash of the applied configuration
+func (r *ReconcileVault) configureKubernetesAuth(ctx context.Context, v *vaultv1alpha1.Vault, leader string) (corev1.ComponentCondition, string) {
+	condition := corev1.ComponentCondition{
+		Type:   vaultv1alpha1.KubernetesAuthConfiguredCondition,
+		Status: corev1.ConditionTrue,
+	}
+
+	hash, err := func() (string, error) {
+		auth, hash, err := kubernetesAuthForVault(v, r.restConfig)
+		if err != nil {
+			return "", err
+		}
+
+		configured := v.Status.GetCondition(vaultv1alpha1.KubernetesAuthConfiguredCondition)
+		if configured != nil && configured.Status == corev1.ConditionTrue && v.Status.KubernetesAuthHash == hash {
+			return hash, nil
+		}
+
+		vaultClient, err := r.httpClients.adminClientForVault(ctx, r.nonNamespacedClient, v, podAddressForVault(v, leader))
+		if err != nil {
+			return "", err
+		}
+
+		if err := applyAuth(vaultClient, []externalAuth{*auth}); err != nil {
+			return "", err
+		}
+
+		r.recorder.Eventf(v, corev1.EventTypeNormal, "KubernetesAuthConfigured",
+			"Configured the Kubernetes auth method at %s with %d role(s)", auth.Path, len(auth.Roles))
+		return hash, nil
+	}()
+	if err != nil {
+		condition.Status = corev1.ConditionFalse
+		condition.Error = err.Error()
+	}
+
+	return condition, hash
+}
+
+// authDelegatorFinalizer makes sure the auth delegator ClusterRoleBinding, which the Vault can't own, is deleted with it
+const authDelegatorFinalizer = "vault.banzaicloud.com/auth-delegator-cleanup"
+
+// bindAuthDelegator binds the ServiceAccount of the Vault to system:auth-delegator while the KubernetesAuth
+// or the OperatorIdentity reviews ServiceAccount tokens, only the ServiceAccount created by the operator is bound,
+// any other ServiceAccount of the namespace would get the cluster wide permissions of the binding
+func (r *ReconcileVault) bindAuthDelegator(ctx context.Context, v *vaultv1alpha1.Vault) (corev1.ComponentCondition, error) {
+	condition := corev1.ComponentCondition{
+		Type:   vaultv1alpha1.AuthDelegatorBoundCondition,
+		Status: corev1.ConditionTrue,
+	}
+
+	if controllerutil.AddFinalizer(v, authDelegatorFinalizer) {
+		if err := r.client.Update(ctx, v); err != nil {
+			return condition, fmt.Errorf("failed to add auth delegator finalizer: %v", err)
+		}
+	}
+
+	serviceAccount := corev1.ServiceAccount{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: v.GetServiceAccountName()}, &serviceAccount)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return condition, fmt.Errorf("failed to get ServiceAccount: %v", err)
+	}
+	if err != nil || !metav1.IsControlledBy(&serviceAccount, v) {
+		if err := r.deleteAuthDelegator(ctx, v, authDelegatorForVault(v).Name, legacyAuthDelegatorName(v)); err != nil {
+			return condition, err
+		}
+		condition.Status = corev1.ConditionFalse
+		condition.Error = fmt.Sprintf("the ServiceAccount %s wasn't created by the operator, bind it to system:auth-delegator, "+
+			"or let the operator manage the RBAC", v.GetServiceAccountName())
+		return condition, nil
+	}
+
+	if err := createOrUpdateObjectWithClient(ctx, r.nonNamespacedClient, authDelegatorForVault(v)); err != nil {
+		return condition, fmt.Errorf("failed to create/update auth delegator ClusterRoleBinding: %v", err)
+	}
+	return condition, r.deleteAuthDelegator(ctx, v, legacyAuthDelegatorName(v))
+}
+
+// releaseAuthDelegator deletes the auth delegator ClusterRoleBinding, and removes the finalizer of it
+func (r *ReconcileVault) releaseAuthDelegator(ctx context.Context, v *vaultv1alpha1.Vault) error {
+	if !controllerutil.ContainsFinalizer(v, authDelegatorFinalizer) {
+		return nil
+	}
+
+	if err := r.deleteAuthDelegator(ctx, v, authDelegatorForVault(v).Name, legacyAuthDelegatorName(v)); err != nil {
+		return err
+	}
+
+	controllerutil.RemoveFinalizer(v, authDelegatorFinalizer)
+	if err := r.client.Update(ctx, v); err != nil {
+		return fmt.Errorf("failed to remove auth delegator finalizer: %v", err)
+	}
+	return nil
+}
+
+// deleteAuthDelegator deletes the auth delegator ClusterRoleBindings of the names which bind the ServiceAccount of the Vault
+func (r *ReconcileVault) deleteAuthDelegator(ctx context.Context, v *vaultv1alpha1.Vault, names ...string) error {
+	for _, name := range names {
+		binding := rbacv1.ClusterRoleBinding{}
+		err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Name: name}, &binding)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to get auth delegator ClusterRoleBinding: %v", err)
+		}
+
+		if !slices.ContainsFunc(binding.Subjects, func(subject rbacv1.Subject) bool {
+			return subject.Kind == rbacv1.ServiceAccountKind && subject.Namespace == v.Namespace && subject.Name == v.GetServiceAccountName()
+		}) {
+			continue
+		}
+		if err := r.nonNamespacedClient.Delete(ctx, &binding); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete auth delegator ClusterRoleBinding: %v", err)
+		}
+	}
+	return nil
+}
+
+// legacyAuthDelegatorName is the name of the auth delegator ClusterRoleBinding before it was made unique
+func legacyAuthDelegatorName(v *vaultv1alpha1.Vault) string {
+	return v.Namespace + "-" + v.Name + "-auth-delegator"
+}
+
+// authDelegatorForVault returns the ClusterRoleBinding allowing Vault to review ServiceAccount tokens
+// with the token of its own ServiceAccount, the hash of the namespace and the name keeps the name unique,
+// "a-b"/"c" and "a"/"b-c" would get the same name otherwise
+func authDelegatorForVault(v *vaultv1alpha1.Vault) *rbacv1.ClusterRoleBinding {
+	hash := sha256.Sum256([]byte(v.Namespace + "/" + v.Name))
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s-%s-auth-delegator-%x", v.Namespace, v.Name, hash[:4]),
+			Annotations: getCommonAnnotations(v, map[string]string{}),
+			Labels:      v.LabelsForVault(),
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     "system:auth-delegator",
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      v.GetServiceAccountName(),
+			Namespace: v.Namespace,
+		}},
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestKubernetesAuthForVault(t *testing.T) {
+	v := &vaultv1alpha1.Vault{ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"}}
+	v.Spec.KubernetesAuth = &vaultv1alpha1.KubernetesAuthConfig{
+		Path: "/k8s/",
+		Roles: []vaultv1alpha1.KubernetesAuthRole{{
+			Name:                          "app",
+			BoundServiceAccountNames:      []string{"app"},
+			BoundServiceAccountNamespaces: []string{"default"},
+			Policies:                      []string{"app"},
+		}},
+	}
+
+	claims := base64.RawURLEncoding.EncodeToString([]byte(`{"iss": "https://kubernetes.default.svc.cluster.local"}`))
+	config := &rest.Config{
+		Host:            "https://10.96.0.1:443",
+		BearerToken:     "header." + claims + ".signature",
+		TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca")},
+	}
+
+	auth, hash, err := kubernetesAuthForVault(v, config)
+	require.NoError(t, err)
+	assert.Equal(t, "k8s", auth.Path)
+	assert.Equal(t, map[string]interface{}{
+		"kubernetes_host":    "https://10.96.0.1:443",
+		"kubernetes_ca_cert": "ca",
+		"issuer":             "https://kubernetes.default.svc.cluster.local",
+	}, auth.Config)
+	assert.Equal(t, []map[string]interface{}{{
+		"name":                             "app",
+		"bound_service_account_names":      []string{"app"},
+		"bound_service_account_namespaces": []string{"default"},
+		"token_policies":                   []string{"app"},
+	}}, auth.Roles)
+
+	// The spec overrides the in-cluster config, and changes the hash
+	v.Spec.KubernetesAuth.Issuer = "https://issuer.example.com"
+	auth, changed, err := kubernetesAuthForVault(v, config)
+	require.NoError(t, err)
+	assert.Equal(t, "https://issuer.example.com", auth.Config["issuer"])
+	assert.NotEqual(t, hash, changed)
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodGet {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"data": {"token/": {"type": "token"}}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	vaultClient, err := api.NewClient(&api.Config{Address: server.URL})
+	require.NoError(t, err)
+	require.NoError(t, applyAuth(vaultClient, []externalAuth{*auth}))
+	assert.Equal(t, []string{
+		"GET /v1/sys/auth",
+		"POST /v1/sys/auth/k8s",
+		"PUT /v1/auth/k8s/config",
+		"PUT /v1/auth/k8s/role/app",
+	}, requests)
+
+	binding := authDelegatorForVault(v)
+	assert.Equal(t, "vault-vault-auth-delegator-ff4defbe", binding.Name)
+	assert.Equal(t, "system:auth-delegator", binding.RoleRef.Name)
+}
+
+func TestBindAuthDelegator(t *testing.T) {
+	v := &vaultv1alpha1.Vault{ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault", UID: "vault-uid"}}
+	v.Spec.KubernetesAuth = &vaultv1alpha1.KubernetesAuthConfig{}
+	v.Spec.RBAC = &vaultv1alpha1.RBAC{Create: true}
+	legacy := authDelegatorForVault(v)
+	legacy.Name = "vault-vault-auth-delegator"
+
+	ctx := context.Background()
+	r, c := newTestReconciler(t, v, legacy)
+
+	// Only the ServiceAccount created by the operator is bound, the binding of the previous name is removed
+	condition, err := r.bindAuthDelegator(ctx, v)
+	require.NoError(t, err)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Contains(t, condition.Error, "the ServiceAccount vault wasn't created by the operator")
+	assert.True(t, controllerutil.ContainsFinalizer(v, authDelegatorFinalizer))
+	assert.True(t, apierrors.IsNotFound(c.Get(ctx, client.ObjectKey{Name: legacy.Name}, &rbacv1.ClusterRoleBinding{})))
+
+	require.NoError(t, r.serviceAccountForVault(ctx, v))
+	condition, err = r.bindAuthDelegator(ctx, v)
+	require.NoError(t, err)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.NoError(t, c.Get(ctx, client.ObjectKey{Name: authDelegatorForVault(v).Name}, &rbacv1.ClusterRoleBinding{}))
+
+	// The binding is deleted once the Vault doesn't review ServiceAccount tokens anymore
+	require.NoError(t, r.releaseAuthDelegator(ctx, v))
+	assert.False(t, controllerutil.ContainsFinalizer(v, authDelegatorFinalizer))
+	assert.True(t, apierrors.IsNotFound(c.Get(ctx, client.ObjectKey{Name: authDelegatorForVault(v).Name}, &rbacv1.ClusterRoleBinding{})))
+}
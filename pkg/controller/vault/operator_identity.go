@@ -0,0 +1,405 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	"github.com/spf13/cast"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// operatorIdentityName is the name of the policy and of the role of the operator
+const operatorIdentityName = "vault-operator"
+
+// operatorTokenTTL is short, the operator logs in for every reconcile
+const operatorTokenTTL = "15m"
+
+// vaultPolicy is a Vault ACL policy with its paths in the order they were granted
+type vaultPolicy struct {
+	paths        []string
+	capabilities map[string][]string
+}
+
+// grant adds capabilities on a path of the policy
+func (p *vaultPolicy) grant(path string, capabilities ...string) {
+	if p.capabilities == nil {
+		p.capabilities = map[string][]string{}
+	}
+	if _, ok := p.capabilities[path]; !ok {
+		p.paths = append(p.paths, path)
+	}
+	for _, capability := range capabilities {
+		if !slices.Contains(p.capabilities[path], capability) {
+			p.capabilities[path] = append(p.capabilities[path], capability)
+		}
+	}
+}
+
+// grantAuth allows to enable, tune and configure an auth method and to write the given roles of it
+func (p *vaultPolicy) grantAuth(path string, roles ...string) {
+	p.grant("sys/auth/"+path, "create", "read", "update", "sudo")
+	p.grant("sys/auth/"+path+"/tune", "read", "update", "sudo")
+	p.grant("sys/mounts/auth/"+path+"/tune", "read", "update", "sudo")
+	p.grant("auth/"+path+"/config", "create", "read", "update")
+	for _, role := range roles {
+		p.grant("auth/"+path+"/role/"+role, "create", "read", "update")
+	}
+}
+
+func (p *vaultPolicy) String() string {
+	var policy strings.Builder
+	for i, path := range p.paths {
+		if i > 0 {
+			policy.WriteString("\n")
+		}
+		capabilities, _ := json.Marshal(p.capabilities[path])
+		fmt.Fprintf(&policy, "path %q {\n  capabilities = %s\n}\n", path, strings.ReplaceAll(string(capabilities), ",", ", "))
+	}
+	return policy.String()
+}
+
+// reservedMountPaths can't be mounted at, a namespace with their name can't have configuration resources
+var reservedMountPaths = []string{"auth", "cubbyhole", "identity", "sys"}
+
+// operatorPolicyForVault returns the policy allowing what the operator does with the root token otherwise: sealing,
+// managing Raft, keeping its own identity up to date and applying the ExternalConfig, the KubernetesAuth, the
+// configuration resources of other namespaces and the transit unseal of the Vaults referencing this one.
+// Only the configured policies and mounts are granted, the configuration resources of the namespace of the Vault
+// and of all namespaces need OperatorIdentityConfig.Policies.
+func operatorPolicyForVault(v *vaultv1alpha1.Vault, transitUnsealVaults []vaultv1alpha1.Vault) (string, error) {
+	var policy vaultPolicy
+
+	policy.grant("sys/seal", "update", "sudo")
+	policy.grant("sys/storage/raft/configuration", "read")
+	policy.grant("sys/storage/raft/remove-peer", "update")
+	policy.grant("sys/auth", "read")
+	policy.grant("sys/mounts", "read")
+	policy.grant("sys/audit", "read", "sudo")
+
+	// The identity is updated by the operator itself once the root token is gone
+	policy.grant("sys/policies/acl/"+operatorIdentityName, "read", "update")
+	policy.grant("sys/auth/"+v.Spec.OperatorIdentity.GetPath(), "read", "sudo")
+	policy.grant("auth/"+v.Spec.OperatorIdentity.GetPath()+"/config", "read", "update")
+	policy.grant("auth/"+v.Spec.OperatorIdentity.GetPath()+"/role/"+operatorIdentityName, "read", "update")
+
+	if len(v.Spec.ExternalConfigJSON()) != 0 {
+		config, err := parseExternalConfig(v.Spec.ExternalConfigJSON())
+		if err != nil {
+			return "", err
+		}
+
+		for _, p := range config.Policies {
+			policy.grant("sys/policies/acl/"+p.Name, "create", "read", "update")
+		}
+		for _, auth := range config.Auth {
+			var roles []string
+			for _, role := range auth.Roles {
+				roles = append(roles, cast.ToString(role["name"]))
+			}
+			policy.grantAuth(mountPath(auth.Path, auth.Type), roles...)
+		}
+		for _, engine := range config.Secrets {
+			path := mountPath(engine.Path, engine.Type)
+			policy.grant("sys/mounts/"+path, "create", "read", "update")
+			policy.grant("sys/mounts/"+path+"/tune", "read", "update")
+			for key, items := range engine.Configuration {
+				for _, item := range items {
+					itemPath := path + "/" + key
+					if name := cast.ToString(item["name"]); name != "" {
+						itemPath += "/" + name
+					}
+					policy.grant(itemPath, "create", "read", "update")
+				}
+			}
+		}
+		for _, audit := range config.Audit {
+			policy.grant("sys/audit/"+mountPath(audit.Path, audit.Type), "create", "read", "update", "delete", "sudo")
+		}
+		for _, startupSecret := range config.StartupSecrets {
+			policy.grant(startupSecret.Path, "create", "update")
+		}
+	}
+
+	if v.Spec.KubernetesAuth != nil {
+		var roles []string
+		for _, role := range v.Spec.KubernetesAuth.Roles {
+			roles = append(roles, role.Name)
+		}
+		policy.grantAuth(v.Spec.KubernetesAuth.GetPath(), roles...)
+	}
+
+	// The configuration resources of other namespaces are kept under their namespace
+	for _, namespace := range v.Spec.ConfigResourceNamespaces {
+		if namespace == "*" || namespace == v.Namespace || slices.Contains(reservedMountPaths, namespace) {
+			continue
+		}
+		policy.grant("sys/policies/acl/"+namespace+".*", "create", "read", "update", "delete")
+		policy.grant("sys/auth/"+namespace+"/*", "create", "read", "update", "delete", "sudo")
+		policy.grant("sys/mounts/auth/"+namespace+"/*", "read", "update")
+		policy.grant("auth/"+namespace+"/*", "create", "read", "update", "delete")
+		policy.grant("sys/mounts/"+namespace+"/*", "create", "read", "update", "delete")
+		policy.grant(namespace+"/*", "create", "read", "update", "delete")
+		policy.grant("sys/audit/"+namespace+"/*", "create", "read", "update", "delete", "sudo")
+	}
+
+	// The Vaults referencing this one for transit unseal get a key and a token allowed to use it,
+	// the Vaults which stopped referencing it have their tokens revoked
+	if len(transitUnsealVaults) != 0 {
+		for _, transitUnsealVault := range transitUnsealVaults {
+			if transitUnsealVault.Spec.UnsealConfig.VaultRef == nil {
+				continue
+			}
+			mountPath := transitUnsealVault.TransitUnsealMountPath()
+			policy.grant("sys/mounts/"+strings.TrimSuffix(mountPath, "/"), "create", "read", "update")
+			policy.grant(mountPath+"keys/"+transitUnsealVault.TransitUnsealKeyName(), "create", "read", "update")
+		}
+		policy.grant("sys/policies/acl/transit-unseal-*", "create", "read", "update", "delete")
+		policy.grant("auth/token/create-orphan", "create", "update", "sudo")
+		policy.grant("auth/token/revoke", "update")
+	}
+
+	return policy.String(), nil
+}
+
+// serviceAccountTokenFile is the ServiceAccount token the operator logs in to Vault with
+var serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// operatorIdentityForVault returns the Kubernetes auth method and the role of the operator, and the SHA256 hash
+// of them and of the policy of the operator
+func operatorIdentityForVault(v *vaultv1alpha1.Vault, config *rest.Config, policy string) (*externalAuth, string, error) {
+	spec := v.Spec.OperatorIdentity
+	authConfig, err := kubernetesAuthConfig(config, "", "")
+	if err != nil {
+		return nil, "", err
+	}
+
+	name, namespace := spec.ServiceAccountName, spec.ServiceAccountNamespace
+	if name == "" || namespace == "" {
+		claims, err := tokenClaims(config)
+		if err != nil {
+			return nil, "", err
+		}
+		// system:serviceaccount:<namespace>:<name>
+		subject := strings.Split(claims.Subject, ":")
+		if len(subject) != 4 || subject[0] != "system" || subject[1] != "serviceaccount" {
+			return nil, "", fmt.Errorf("the ServiceAccount of the operator can't be found in its token, set serviceAccountName and serviceAccountNamespace")
+		}
+		if namespace == "" {
+			namespace = subject[2]
+		}
+		if name == "" {
+			name = subject[3]
+		}
+	}
+
+	auth := &externalAuth{
+		Type:        "kubernetes",
+		Path:        spec.GetPath(),
+		Description: "Identity of the Bank-Vaults operator",
+		Config:      authConfig,
+		Roles: []map[string]interface{}{{
+			"name":                             operatorIdentityName,
+			"bound_service_account_names":      []string{name},
+			"bound_service_account_namespaces": []string{namespace},
+			"token_policies":                   append([]string{operatorIdentityName}, spec.Policies...),
+			"token_ttl":                        operatorTokenTTL,
+			"token_max_ttl":                    operatorTokenTTL,
+		}},
+	}
+
+	data, err := json.Marshal(auth)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return auth, fmt.Sprintf("%x", sha256.Sum256(append(data, policy...))), nil
+}
+
+// loginOperator authenticates the Vault client with the ServiceAccount token of the operator
+func loginOperator(ctx context.Context, vaultClient *api.Client, v *vaultv1alpha1.Vault) (*api.SecretAuth, error) {
+	jwt, err := os.ReadFile(serviceAccountTokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ServiceAccount token: %v", err)
+	}
+
+	path := v.Spec.OperatorIdentity.GetPath()
+	secret, err := vaultClient.Logical().WriteWithContext(ctx, "auth/"+path+"/login", map[string]interface{}{
+		"role": operatorIdentityName,
+		"jwt":  strings.TrimSpace(string(jwt)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to log in to vault with the identity of the operator: %v", err)
+	}
+	if secret == nil || secret.Auth == nil {
+		return nil, fmt.Errorf("failed to log in to vault with the identity of the operator: no token returned")
+	}
+
+	vaultClient.SetToken(secret.Auth.ClientToken)
+	return secret.Auth, nil
+}
+
+// authenticateOperator authenticates the Vault client with the cached token of the operator identity, the token
+// is renewed once half of its TTL is over, and replaced by a new login once it can't be renewed anymore
+func (cache *vaultHTTPClientCache) authenticateOperator(ctx context.Context, vaultClient *api.Client, v *vaultv1alpha1.Vault) error {
+	key := types.NamespacedName{Namespace: v.Namespace, Name: v.Name}
+	if cached := cache.cachedOperatorToken(key, v.Status.OperatorIdentityHash); cached != nil {
+		remaining := time.Until(cached.expiry)
+		if cached.expiry.IsZero() || remaining > cached.ttl/2 {
+			vaultClient.SetToken(cached.token)
+			return nil
+		}
+
+		if cached.renewable && remaining > 0 {
+			vaultClient.SetToken(cached.token)
+			secret, err := vaultClient.Auth().Token().RenewSelfWithContext(ctx, 0)
+			if err == nil && secret != nil && secret.Auth != nil && secret.Auth.LeaseDuration > 0 {
+				cache.cacheOperatorToken(key, newOperatorToken(v, cached.token, secret.Auth))
+				return nil
+			}
+		}
+	}
+
+	auth, err := loginOperator(ctx, vaultClient, v)
+	if err != nil {
+		return err
+	}
+	cache.cacheOperatorToken(key, newOperatorToken(v, auth.ClientToken, auth))
+	return nil
+}
+
+// newOperatorToken returns the cached token of the operator identity with the lease of the login or the renewal
+func newOperatorToken(v *vaultv1alpha1.Vault, token string, auth *api.SecretAuth) *operatorToken {
+	cached := &operatorToken{
+		identityHash: v.Status.OperatorIdentityHash,
+		token:        token,
+		renewable:    auth.Renewable,
+		ttl:          time.Duration(auth.LeaseDuration) * time.Second,
+	}
+	if cached.ttl > 0 {
+		cached.expiry = time.Now().Add(cached.ttl)
+	}
+	return cached
+}
+
+// bootstrapOperatorIdentity creates or updates the identity of the operator in the leader unless it is up to date,
+// and drops the root token if it shouldn't be stored, it returns the resulting condition and the hash of the identity
+func (r *ReconcileVault) bootstrapOperatorIdentity(ctx context.Context, v *vaultv1alpha1.Vault, leader string) (corev1.ComponentCondition, string) {
+	condition := corev1.ComponentCondition{
+		Type:   vaultv1alpha1.OperatorIdentityCondition,
+		Status: corev1.ConditionTrue,
+	}
+
+	hash, err := func() (string, error) {
+		transitUnsealVaults, err := r.transitUnsealVaultsFor(ctx, v)
+		if err != nil {
+			return "", err
+		}
+		policy, err := operatorPolicyForVault(v, transitUnsealVaults)
+		if err != nil {
+			return "", err
+		}
+		auth, hash, err := operatorIdentityForVault(v, r.restConfig, policy)
+		if err != nil {
+			return "", err
+		}
+		if v.Status.OperatorIdentityHash == hash {
+			return hash, nil
+		}
+
+		// The root token bootstraps the identity, later changes are applied with the identity itself
+		vaultClient, err := r.httpClients.adminClientForVault(ctx, r.nonNamespacedClient, v, podAddressForVault(v, leader))
+		if err != nil {
+			if v.Status.OperatorIdentityHash == "" {
+				return "", fmt.Errorf("the root token is needed once to bootstrap the identity of the operator: %v", err)
+			}
+			return "", err
+		}
+
+		if err := vaultClient.Sys().PutPolicyWithContext(ctx, operatorIdentityName, policy); err != nil {
+			return "", fmt.Errorf("failed to write the policy of the operator: %v", err)
+		}
+		if err := applyAuth(vaultClient, []externalAuth{*auth}); err != nil {
+			return "", err
+		}
+
+		// The identity has to work before the root token is dropped
+		identityClient, err := vaultClient.Clone()
+		if err != nil {
+			return "", err
+		}
+		if _, err := loginOperator(ctx, identityClient, v); err != nil {
+			return "", err
+		}
+
+		if storeRootToken := v.Spec.UnsealConfig.Options.StoreRootToken; storeRootToken != nil && !*storeRootToken {
+			if err := r.dropRootToken(ctx, v, vaultClient); err != nil {
+				return "", err
+			}
+		}
+
+		r.recorder.Eventf(v, corev1.EventTypeNormal, "OperatorIdentityBootstrapped",
+			"The operator authenticates with the %s role of the %s auth method", operatorIdentityName, auth.Path)
+		return hash, nil
+	}()
+	if err != nil {
+		condition.Status = corev1.ConditionFalse
+		condition.Error = err.Error()
+	}
+
+	return condition, hash
+}
+
+// dropRootToken revokes the root token stored at init and removes it from the unseal keys Secret
+func (r *ReconcileVault) dropRootToken(ctx context.Context, v *vaultv1alpha1.Vault, vaultClient *api.Client) error {
+	secretNamespace, secretName := v.Spec.UnsealConfig.KubernetesSecret(v)
+	secret := corev1.Secret{}
+	err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: secretNamespace, Name: secretName}, &secret)
+	if err != nil {
+		return fmt.Errorf("failed to get unseal keys secret: %v", err)
+	}
+
+	if _, ok := secret.Data[rootTokenKey]; !ok {
+		return nil
+	}
+
+	token, err := rootTokenForVault(ctx, r.nonNamespacedClient, v)
+	if err != nil {
+		return err
+	}
+	if err := revokeRootToken(ctx, vaultClient, token); err != nil {
+		return err
+	}
+
+	delete(secret.Data, rootTokenKey)
+	if err := r.nonNamespacedClient.Update(ctx, &secret); err != nil {
+		return fmt.Errorf("failed to remove the root token from secret %s/%s: %v", secretNamespace, secretName, err)
+	}
+
+	r.recorder.Event(v, corev1.EventTypeNormal, "RootTokenRevoked", "Revoked the root token, the operator uses its own identity")
+	return nil
+}
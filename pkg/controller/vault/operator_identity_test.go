@@ -0,0 +1,316 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestOperatorIdentity(t *testing.T) {
+	v := &vaultv1alpha1.Vault{ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"}}
+	v.Spec.UnsealConfig.Options.StoreRootToken = ptr.To(false)
+	assert.Contains(t, v.Spec.UnsealConfig.ToArgs(v), "--store-root-token=false")
+
+	// The root token is kept at init to bootstrap the identity
+	v.Spec.OperatorIdentity = &vaultv1alpha1.OperatorIdentityConfig{Policies: []string{"startup-secrets"}}
+	assert.True(t, v.Spec.InitStoresRootToken())
+	assert.NotContains(t, v.Spec.UnsealConfig.ToArgs(v), "--store-root-token=false")
+
+	claims := base64.RawURLEncoding.EncodeToString([]byte(`{"iss": "kubernetes/serviceaccount", "sub": "system:serviceaccount:operators:vault-operator"}`))
+	config := &rest.Config{Host: "https://10.96.0.1:443", BearerToken: "header." + claims + ".signature"}
+
+	policy, err := operatorPolicyForVault(v, nil)
+	require.NoError(t, err)
+	auth, hash, err := operatorIdentityForVault(v, config, policy)
+	require.NoError(t, err)
+	assert.Equal(t, "vault-operator", auth.Path)
+	assert.Equal(t, []map[string]interface{}{{
+		"name":                             "vault-operator",
+		"bound_service_account_names":      []string{"vault-operator"},
+		"bound_service_account_namespaces": []string{"operators"},
+		"token_policies":                   []string{"vault-operator", "startup-secrets"},
+		"token_ttl":                        "15m",
+		"token_max_ttl":                    "15m",
+	}}, auth.Roles)
+
+	v.Spec.OperatorIdentity.ServiceAccountName = "operator"
+	auth, changed, err := operatorIdentityForVault(v, config, policy)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"operator"}, auth.Roles[0]["bound_service_account_names"])
+	assert.NotEqual(t, hash, changed)
+
+	// Without a ServiceAccount token the ServiceAccount has to be set
+	_, _, err = operatorIdentityForVault(v, &rest.Config{Host: "https://10.96.0.1:443"}, policy)
+	assert.Error(t, err)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("operator-jwt\n"), 0o600))
+	defer func(file string) { serviceAccountTokenFile = file }(serviceAccountTokenFile)
+	serviceAccountTokenFile = tokenFile
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.Header.Get("X-Vault-Token"))
+		if r.URL.Path == "/v1/auth/vault-operator/login" {
+			body := map[string]interface{}{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, map[string]interface{}{"role": "vault-operator", "jwt": "operator-jwt"}, body)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"auth": {"client_token": "hvs.operator", "lease_duration": 900, "renewable": true}}`))
+			return
+		}
+		if r.URL.Path == "/v1/auth/token/renew-self" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"auth": {"client_token": "hvs.operator", "lease_duration": 600, "renewable": true}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	keys := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-unseal-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"vault-unseal-0": []byte("key-0"), "vault-root": []byte("hvs.root")},
+	}
+	tlsSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "vault-tls", Namespace: "vault"}}
+	r, c := newTestReconciler(t, keys, tlsSecret)
+
+	// The root token is used until the identity is bootstrapped
+	vaultClient, err := r.httpClients.adminClientForVault(context.Background(), c, v, server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "hvs.root", vaultClient.Token())
+
+	require.NoError(t, r.dropRootToken(context.Background(), v, vaultClient))
+	dropped := corev1.Secret{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(keys), &dropped))
+	assert.NotContains(t, dropped.Data, "vault-root")
+	assert.Contains(t, dropped.Data, "vault-unseal-0")
+
+	v.Status.OperatorIdentityHash = changed
+	vaultClient, err = r.httpClients.adminClientForVault(context.Background(), c, v, server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "hvs.operator", vaultClient.Token())
+
+	// The token is cached with the HTTP client, renewed once half of its TTL is over, and replaced once expired
+	vaultClient, err = r.httpClients.adminClientForVault(context.Background(), c, v, server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "hvs.operator", vaultClient.Token())
+
+	key := client.ObjectKeyFromObject(v)
+	cached := *r.httpClients.cachedOperatorToken(key, changed)
+	cached.expiry = time.Now().Add(5 * time.Minute)
+	r.httpClients.cacheOperatorToken(key, &cached)
+	_, err = r.httpClients.adminClientForVault(context.Background(), c, v, server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, r.httpClients.cachedOperatorToken(key, changed).ttl)
+
+	cached.expiry = time.Now().Add(-time.Second)
+	r.httpClients.cacheOperatorToken(key, &cached)
+	_, err = r.httpClients.adminClientForVault(context.Background(), c, v, server.URL)
+	require.NoError(t, err)
+
+	// A changed identity logs in again
+	assert.Nil(t, r.httpClients.cachedOperatorToken(key, hash))
+
+	assert.Equal(t, []string{
+		"PUT /v1/auth/token/revoke-self hvs.root",
+		"PUT /v1/auth/vault-operator/login ",
+		"PUT /v1/auth/token/renew-self hvs.operator",
+		"PUT /v1/auth/vault-operator/login ",
+	}, requests)
+}
+
+// policyAllows evaluates a policy rendered by vaultPolicy like Vault does for the requests of the operator,
+// the most specific matching path applies
+func policyAllows(policy, method, path string, list bool) bool {
+	var capabilities []string
+	best := ""
+	for _, match := range regexp.MustCompile(`path "(.+)" \{\n  capabilities = (\[.*\])\n\}`).FindAllStringSubmatch(policy, -1) {
+		pattern := match[1]
+		if !policyPathMatches(pattern, path) || len(pattern) < len(best) || pattern != path && best == path {
+			continue
+		}
+		best = pattern
+		capabilities = nil
+		_ = json.Unmarshal([]byte(match[2]), &capabilities)
+	}
+
+	var required []string
+	switch {
+	case method == http.MethodGet && list:
+		required = []string{"list"}
+	case method == http.MethodGet:
+		required = []string{"read"}
+	case method == http.MethodDelete:
+		required = []string{"delete"}
+	case slices.Contains(capabilities, "create"):
+		required = []string{"create"}
+	default:
+		required = []string{"update"}
+	}
+	for _, root := range []string{"sys/audit", "sys/auth/", "sys/mounts/auth/", "sys/seal", "auth/token/create-orphan"} {
+		if strings.HasPrefix(path, root) {
+			required = append(required, "sudo")
+		}
+	}
+
+	for _, capability := range required {
+		if !slices.Contains(capabilities, capability) {
+			return false
+		}
+	}
+	return true
+}
+
+func policyPathMatches(pattern, path string) bool {
+	prefix, glob := strings.CutSuffix(pattern, "*")
+	patternSegments := strings.Split(prefix, "/")
+	pathSegments := strings.Split(path, "/")
+	if len(pathSegments) < len(patternSegments) || !glob && len(pathSegments) != len(patternSegments) {
+		return false
+	}
+	for i, segment := range patternSegments {
+		last := i == len(patternSegments)-1
+		switch {
+		case segment == "+":
+		case last && glob:
+			return strings.HasPrefix(pathSegments[i], segment)
+		case segment != pathSegments[i]:
+			return false
+		}
+	}
+	return true
+}
+
+func TestOperatorPolicyAppliesExternalConfig(t *testing.T) {
+	v := &vaultv1alpha1.Vault{ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"}}
+	v.Spec.OperatorIdentity = &vaultv1alpha1.OperatorIdentityConfig{}
+	v.Spec.ExternalConfig = extv1beta1.JSON{Raw: []byte(`{
+		"policies": [{"name": "allow_secrets", "rules": "path \"secret/*\" { capabilities = [\"read\"] }"}],
+		"auth": [{
+			"type": "kubernetes",
+			"options": {"default_lease_ttl": "1h"},
+			"config": {"kubernetes_host": "https://kubernetes.default.svc"},
+			"roles": [{"name": "default", "policies": "allow_secrets"}]
+		}],
+		"secrets": [
+			{"type": "kv", "path": "secret", "options": {"version": 2}},
+			{
+				"type": "pki",
+				"config": {"max_lease_ttl": "720h"},
+				"configuration": {
+					"root/generate": [{"name": "internal", "common_name": "vault"}],
+					"roles": [{"name": "default", "allowed_domains": "svc"}]
+				}
+			}
+		],
+		"audit": [{"type": "file", "options": {"file_path": "/vault/logs/audit.log"}}],
+		"startupSecrets": [{"type": "kv", "path": "secret/data/app", "data": {"data": {"key": "value"}}}]
+	}`)}
+	v.Spec.ConfigResourceNamespaces = []string{"team-a", "sys"}
+
+	policy, err := operatorPolicyForVault(v, nil)
+	require.NoError(t, err)
+
+	// The policy is limited to the configured policies and mounts
+	assert.NotContains(t, policy, `"sys/policies/acl/*"`)
+	assert.NotContains(t, policy, `"sys/auth/*"`)
+	assert.NotContains(t, policy, `"auth/*"`)
+	assert.NotContains(t, policy, `"sys/*"`)
+	assert.NotContains(t, policy, "create-orphan")
+	assert.True(t, policyAllows(policy, http.MethodPut, "team-a/secrets/config", false))
+	assert.False(t, policyAllows(policy, http.MethodPut, "sys/policies/acl/team-b.reader", false))
+	assert.False(t, policyAllows(policy, http.MethodPut, "auth/kubernetes/role/admin", false))
+
+	mounted := false
+	var denied []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/v1/")
+		if !policyAllows(policy, r.Method, path, r.URL.Query().Get("list") == "true") {
+			denied = append(denied, r.Method+" "+path)
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method != http.MethodGet:
+			w.WriteHeader(http.StatusNoContent)
+		case path == "sys/auth" && mounted:
+			_, _ = w.Write([]byte(`{"data": {"kubernetes/": {"type": "kubernetes"}, "vault-operator/": {"type": "kubernetes"}}}`))
+		case path == "sys/mounts" && mounted:
+			_, _ = w.Write([]byte(`{"data": {"secret/": {"type": "kv", "options": {"version": "1"}}, "pki/": {"type": "pki"}}}`))
+		case path == "sys/audit" && mounted:
+			_, _ = w.Write([]byte(`{"data": {"file/": {"type": "file", "options": {"file_path": "/tmp/audit.log"}}}}`))
+		case path == "sys/auth" || path == "sys/mounts" || path == "sys/audit":
+			_, _ = w.Write([]byte(`{"data": {}}`))
+		case path == "pki/root/generate/internal":
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	vaultClient, err := api.NewClient(&api.Config{Address: server.URL})
+	require.NoError(t, err)
+	config, err := parseExternalConfig(v.Spec.ExternalConfigJSON())
+	require.NoError(t, err)
+
+	claims := base64.RawURLEncoding.EncodeToString([]byte(`{"iss": "kubernetes/serviceaccount", "sub": "system:serviceaccount:operators:vault-operator"}`))
+	identity, _, err := operatorIdentityForVault(v, &rest.Config{Host: "https://10.96.0.1:443", BearerToken: "header." + claims + ".signature"}, policy)
+	require.NoError(t, err)
+
+	// The ExternalConfig is applied on an empty Vault, then again on a drifted one, and checked for drift
+	require.NoError(t, applyExternalConfig(vaultClient, config))
+	mounted = true
+	require.NoError(t, vaultClient.Sys().PutPolicy(operatorIdentityName, policy))
+	require.NoError(t, applyAuth(vaultClient, []externalAuth{*identity}))
+	require.NoError(t, applyExternalConfig(vaultClient, config))
+	_, err = detectDrift(vaultClient, config)
+	require.NoError(t, err)
+	assert.Empty(t, denied)
+
+	// The Vaults auto-unsealing with this one only get their own transit key
+	transitUnsealVault := vaultv1alpha1.Vault{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "apps"}}
+	transitUnsealVault.Spec.UnsealConfig.VaultRef = &vaultv1alpha1.VaultRefUnsealConfig{Name: "vault", Namespace: "vault"}
+	policy, err = operatorPolicyForVault(v, []vaultv1alpha1.Vault{transitUnsealVault})
+	require.NoError(t, err)
+	assert.True(t, policyAllows(policy, http.MethodPut, "transit/keys/apps-app", false))
+	assert.False(t, policyAllows(policy, http.MethodPut, "secret/keys/apps-app", false))
+	assert.True(t, policyAllows(policy, http.MethodPut, "auth/token/create-orphan", false))
+}
@@ -0,0 +1,214 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// rbacForVault creates the ServiceAccount of the Vault Pods, and the Roles and RoleBindings
+// needed by the features enabled in the Vault spec
+func (r *ReconcileVault) rbacForVault(ctx context.Context, v *vaultv1alpha1.Vault) error {
+	if !v.Spec.IsRBACManaged() {
+		return nil
+	}
+
+	if err := r.serviceAccountForVault(ctx, v); err != nil {
+		return err
+	}
+
+	roles, roleBindings := rolesForVault(v)
+	for i := range roles {
+		// Objects in other namespaces can't be owned by the Vault, so they are left behind when it gets deleted
+		c := r.nonNamespacedClient
+		if roles[i].Namespace == v.Namespace {
+			c = r.client
+			if err := controllerutil.SetControllerReference(v, roles[i], r.scheme); err != nil {
+				return err
+			}
+			if err := controllerutil.SetControllerReference(v, roleBindings[i], r.scheme); err != nil {
+				return err
+			}
+		}
+
+		if err := createOrUpdateObjectWithClient(ctx, c, roles[i]); err != nil {
+			return fmt.Errorf("failed to create/update Role in namespace %s: %v", roles[i].Namespace, err)
+		}
+		if err := createOrUpdateObjectWithClient(ctx, c, roleBindings[i]); err != nil {
+			return fmt.Errorf("failed to create/update RoleBinding in namespace %s: %v", roleBindings[i].Namespace, err)
+		}
+	}
+
+	return nil
+}
+
+// serviceAccountForVault creates the ServiceAccount of the Vault Pods if it doesn't exist, and only updates
+// the ServiceAccount the Vault owns, an existing one of the spec, like default, is used as it is
+func (r *ReconcileVault) serviceAccountForVault(ctx context.Context, v *vaultv1alpha1.Vault) error {
+	serviceAccount := newServiceAccountForVault(v)
+
+	current := &corev1.ServiceAccount{}
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(serviceAccount), current)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get ServiceAccount: %v", err)
+	}
+	if err == nil && !metav1.IsControlledBy(current, v) {
+		if len(v.Spec.RBAC.ServiceAccountAnnotations) != 0 {
+			r.recorder.Eventf(v, corev1.EventTypeWarning, "ServiceAccountNotOwned",
+				"The ServiceAccount %s wasn't created by the operator, its annotations are not managed", current.Name)
+		}
+		return nil
+	}
+
+	if err := controllerutil.SetControllerReference(v, serviceAccount, r.scheme); err != nil {
+		return err
+	}
+	if err := r.createOrUpdateObject(ctx, serviceAccount); err != nil {
+		return fmt.Errorf("failed to create/update ServiceAccount: %v", err)
+	}
+	return nil
+}
+
+func newServiceAccountForVault(v *vaultv1alpha1.Vault) *corev1.ServiceAccount {
+	annotations := getCommonAnnotations(v, map[string]string{})
+	for key, value := range v.Spec.RBAC.ServiceAccountAnnotations {
+		annotations[key] = value
+	}
+
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        v.GetServiceAccountName(),
+			Namespace:   v.Namespace,
+			Annotations: annotations,
+			Labels:      v.LabelsForVault(),
+		},
+	}
+}
+
+// rolesForVault returns the Roles and the matching RoleBindings of the Vault ServiceAccount, the Role in the
+// namespace of the Vault is always returned, so it gets emptied if the features are disabled
+func rolesForVault(v *vaultv1alpha1.Vault) ([]*rbacv1.Role, []*rbacv1.RoleBinding) {
+	rules := map[string][]rbacv1.PolicyRule{v.Namespace: {}}
+
+	// The Vault service registration labels the active and standby Pods
+	if v.Spec.ServiceRegistrationEnabled {
+		rules[v.Namespace] = append(rules[v.Namespace], rbacv1.PolicyRule{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get", "update", "patch"},
+		})
+	}
+
+	// The unsealer stores the unseal keys and the root token in a Secret
+	unseal := v.Spec.UnsealConfig
+	secretNamespace, secretName := "", ""
+	if unseal.IsKubernetes() || unseal.Custodians != nil {
+		secretNamespace, secretName = unseal.KubernetesSecret(v)
+	} else if unseal.HSM != nil && unseal.Kubernetes.SecretNamespace != "" && unseal.Kubernetes.SecretName != "" {
+		secretNamespace, secretName = unseal.Kubernetes.SecretNamespace, unseal.Kubernetes.SecretName
+	}
+	if secretName != "" {
+		rules[secretNamespace] = append(rules[secretNamespace],
+			rbacv1.PolicyRule{
+				APIGroups:     []string{""},
+				Resources:     []string{"secrets"},
+				ResourceNames: []string{secretName},
+				Verbs:         []string{"get", "update", "patch"},
+			},
+			// Create requests can't be restricted by name
+			rbacv1.PolicyRule{
+				APIGroups: []string{""},
+				Resources: []string{"secrets"},
+				Verbs:     []string{"create"},
+			},
+		)
+	}
+
+	// The key decryptor sidecars read the encryption of the Vault and the key encryption key
+	if unseal.IsEncrypted() {
+		rules[v.Namespace] = append(rules[v.Namespace], rbacv1.PolicyRule{
+			APIGroups:     []string{vaultv1alpha1.SchemeGroupVersion.Group},
+			Resources:     []string{"vaults"},
+			ResourceNames: []string{v.Name},
+			Verbs:         []string{"get"},
+		})
+		if kekRef := unseal.Kubernetes.Encryption.KEKSecretRef; kekRef != nil {
+			kekNamespace := v.Namespace
+			if unseal.Kubernetes.Encryption.KEKSecretNamespace != "" {
+				kekNamespace = unseal.Kubernetes.Encryption.KEKSecretNamespace
+			}
+			rules[kekNamespace] = append(rules[kekNamespace], rbacv1.PolicyRule{
+				APIGroups:     []string{""},
+				Resources:     []string{"secrets"},
+				ResourceNames: []string{kekRef.Name},
+				Verbs:         []string{"get"},
+			})
+		}
+	}
+
+	namespaces := []string{v.Namespace}
+	for namespace := range rules {
+		if namespace != v.Namespace {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	sort.Strings(namespaces[1:])
+
+	var roles []*rbacv1.Role
+	var roleBindings []*rbacv1.RoleBinding
+	for _, namespace := range namespaces {
+		name := v.Name
+		if namespace != v.Namespace {
+			name = v.Namespace + "-" + v.Name
+		}
+
+		meta := metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: getCommonAnnotations(v, map[string]string{}),
+			Labels:      v.LabelsForVault(),
+		}
+
+		roles = append(roles, &rbacv1.Role{
+			ObjectMeta: meta,
+			Rules:      rules[namespace],
+		})
+		roleBindings = append(roleBindings, &rbacv1.RoleBinding{
+			ObjectMeta: *meta.DeepCopy(),
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     name,
+			},
+			Subjects: []rbacv1.Subject{{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      v.GetServiceAccountName(),
+				Namespace: v.Namespace,
+			}},
+		})
+	}
+
+	return roles, roleBindings
+}
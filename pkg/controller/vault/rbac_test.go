@@ -0,0 +1,96 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRolesForVault(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"},
+		Spec: vaultv1alpha1.VaultSpec{
+			ServiceRegistrationEnabled: true,
+			RBAC: &vaultv1alpha1.RBAC{
+				Create:                    true,
+				ServiceAccountAnnotations: map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/vault"},
+			},
+			UnsealConfig: vaultv1alpha1.UnsealConfig{
+				Kubernetes: vaultv1alpha1.KubernetesUnsealConfig{SecretNamespace: "vault-keys"},
+			},
+		},
+	}
+
+	assert.Equal(t, "vault", v.GetServiceAccountName())
+	assert.Equal(t, "arn:aws:iam::123456789012:role/vault", newServiceAccountForVault(v).Annotations["eks.amazonaws.com/role-arn"])
+
+	roles, roleBindings := rolesForVault(v)
+	require.Len(t, roles, 2)
+	require.Len(t, roleBindings, 2)
+
+	assert.Equal(t, "vault", roles[0].Namespace)
+	require.Len(t, roles[0].Rules, 1)
+	assert.Equal(t, []string{"pods"}, roles[0].Rules[0].Resources)
+
+	assert.Equal(t, "vault-keys", roles[1].Namespace)
+	assert.Equal(t, "vault-vault", roles[1].Name)
+	assert.Equal(t, []string{"vault-unseal-keys"}, roles[1].Rules[0].ResourceNames)
+	assert.Equal(t, "vault", roleBindings[1].Subjects[0].Namespace)
+
+	// Auto unseal needs no Kubernetes permissions, the Role is kept empty
+	v.Spec.ServiceRegistrationEnabled = false
+	v.Spec.UnsealConfig.AWS = &vaultv1alpha1.AWSUnsealConfig{KMSKeyID: "key"}
+	roles, _ = rolesForVault(v)
+	require.Len(t, roles, 1)
+	assert.Empty(t, roles[0].Rules)
+}
+
+func TestServiceAccountForVault(t *testing.T) {
+	ctx := context.Background()
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault", UID: "uid"},
+		Spec: vaultv1alpha1.VaultSpec{
+			RBAC: &vaultv1alpha1.RBAC{
+				Create:                    true,
+				ServiceAccountAnnotations: map[string]string{"iam.gke.io/gcp-service-account": "vault@project.iam.gserviceaccount.com"},
+			},
+		},
+	}
+	existing := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "vault"}}
+	r, c := newTestReconciler(t, v, existing)
+
+	// The ServiceAccount named by the operator is created and owned
+	require.NoError(t, r.serviceAccountForVault(ctx, v))
+	created := &corev1.ServiceAccount{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "vault", Name: "vault"}, created))
+	assert.True(t, metav1.IsControlledBy(created, v))
+
+	// An existing ServiceAccount of the spec is used as it is
+	v.Spec.ServiceAccount = "default"
+	require.NoError(t, r.serviceAccountForVault(ctx, v))
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(existing), existing))
+	assert.Empty(t, existing.OwnerReferences)
+	assert.Empty(t, existing.Annotations)
+	assert.Contains(t, <-r.recorder.(*record.FakeRecorder).Events, "ServiceAccountNotOwned")
+}
@@ -0,0 +1,138 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// recoveryKeysForVault reports the recovery keys of an auto-unsealed Vault, and moves them from the Secret
+// of the unsealer to their own Secret when one is configured
+func (r *ReconcileVault) recoveryKeysForVault(ctx context.Context, v *vaultv1alpha1.Vault, leader string) (*vaultv1alpha1.RecoveryKeysStatus, error) {
+	vaultClient, err := r.httpClients.vaultClientForPod(ctx, r.client, v, leader)
+	if err != nil {
+		return nil, err
+	}
+
+	sealStatus, err := vaultClient.Sys().SealStatusWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seal status: %v", err)
+	}
+
+	status := &vaultv1alpha1.RecoveryKeysStatus{
+		Generated: sealStatus.Initialized && sealStatus.RecoverySeal,
+		Location:  v.Spec.UnsealConfig.Backend(),
+	}
+	if status.Generated {
+		status.Shares = sealStatus.N
+		status.Threshold = sealStatus.T
+	}
+
+	// The keys can only be found in Kubernetes Secrets
+	if !v.Spec.UnsealConfig.IsKubernetes() {
+		return status, nil
+	}
+
+	unsealNamespace, unsealName := v.Spec.UnsealConfig.KubernetesSecret(v)
+	recoveryNamespace, recoveryName := v.Spec.UnsealConfig.RecoveryKeysSecret(v)
+	unsealKey := client.ObjectKey{Namespace: unsealNamespace, Name: unsealName}
+	recoveryKey := client.ObjectKey{Namespace: recoveryNamespace, Name: recoveryName}
+	status.Location = recoveryKey.String()
+
+	if status.Generated && recoveryKey != unsealKey {
+		if err := r.moveRecoveryKeys(ctx, v, unsealKey, recoveryKey); err != nil {
+			return status, err
+		}
+	}
+
+	secret := corev1.Secret{}
+	err = r.nonNamespacedClient.Get(ctx, recoveryKey, &secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return status, fmt.Errorf("failed to get recovery keys secret: %v", err)
+	}
+	status.Stored = len(storedKeys(&secret, recoveryKeyPrefix))
+
+	return status, nil
+}
+
+// moveRecoveryKeys moves the recovery keys stored by the unsealer to their own Secret,
+// they are only removed from the Secret of the unsealer once they have been copied
+func (r *ReconcileVault) moveRecoveryKeys(ctx context.Context, v *vaultv1alpha1.Vault, from, to client.ObjectKey) error {
+	source := corev1.Secret{}
+	err := r.nonNamespacedClient.Get(ctx, from, &source)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get unseal keys secret: %v", err)
+	}
+
+	keys := map[string][]byte{}
+	for key, value := range source.Data {
+		if strings.HasPrefix(key, recoveryKeyPrefix) {
+			keys[key] = value
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	destination := corev1.Secret{}
+	err = r.nonNamespacedClient.Get(ctx, to, &destination)
+	if apierrors.IsNotFound(err) {
+		destination = corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: to.Name, Namespace: to.Namespace, Labels: v.LabelsForVault()},
+			Data:       keys,
+		}
+		if err := r.nonNamespacedClient.Create(ctx, &destination); err != nil {
+			return fmt.Errorf("failed to create recovery keys secret: %v", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to get recovery keys secret: %v", err)
+	} else {
+		// Keys of a previous initialisation are replaced as a whole
+		for key := range destination.Data {
+			if strings.HasPrefix(key, recoveryKeyPrefix) {
+				delete(destination.Data, key)
+			}
+		}
+		if destination.Data == nil {
+			destination.Data = map[string][]byte{}
+		}
+		for key, value := range keys {
+			destination.Data[key] = value
+		}
+		if err := r.nonNamespacedClient.Update(ctx, &destination); err != nil {
+			return fmt.Errorf("failed to update recovery keys secret: %v", err)
+		}
+	}
+
+	for key := range keys {
+		delete(source.Data, key)
+	}
+	if err := r.nonNamespacedClient.Update(ctx, &source); err != nil {
+		return fmt.Errorf("failed to remove recovery keys from unseal keys secret: %v", err)
+	}
+
+	r.recorder.Eventf(v, corev1.EventTypeNormal, "RecoveryKeysMoved", "Moved %d recovery keys from secret %s to secret %s", len(keys), from, to)
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestMoveRecoveryKeys(t *testing.T) {
+	v := &vaultv1alpha1.Vault{ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"}}
+	v.Spec.UnsealConfig.RecoveryKeys = &vaultv1alpha1.RecoveryKeysConfig{SecretNamespace: "audit"}
+	keys := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-unseal-keys", Namespace: "vault"},
+		Data: map[string][]byte{
+			"vault-recovery-0": []byte("key-0"),
+			"vault-recovery-1": []byte("key-1"),
+			"vault-root":       []byte("root"),
+		},
+	}
+
+	r, c := newTestReconciler(t, keys)
+
+	from := client.ObjectKeyFromObject(keys)
+	to := client.ObjectKey{Namespace: "audit", Name: "vault-recovery-keys"}
+	require.NoError(t, r.moveRecoveryKeys(context.Background(), v, from, to))
+
+	// The root token stays with the unsealer
+	unseal := corev1.Secret{}
+	require.NoError(t, c.Get(context.Background(), from, &unseal))
+	assert.Equal(t, map[string][]byte{"vault-root": []byte("root")}, unseal.Data)
+
+	recovery := corev1.Secret{}
+	require.NoError(t, c.Get(context.Background(), to, &recovery))
+	assert.Equal(t, []string{"key-0", "key-1"}, storedKeys(&recovery, recoveryKeyPrefix))
+
+	// Nothing left to move
+	require.NoError(t, r.moveRecoveryKeys(context.Background(), v, from, to))
+}
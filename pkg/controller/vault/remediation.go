@@ -0,0 +1,381 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/spf13/cast"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Reasons of the unhealthy pods
+const (
+	podSealed        = "Sealed"
+	podUninitialized = "Uninitialized"
+	podUnreachable   = "Unreachable"
+	// podClaimsRemoved is a pod recreated before the volume claims deleted by a raft rejoin were gone
+	podClaimsRemoved = "VolumeClaimsRemoved"
+)
+
+// healthCheckTimeout keeps the unreachable pods from blocking the reconcile
+const healthCheckTimeout = 5 * time.Second
+
+// remediatePods checks the health of every Vault pod, and acts on the pods unhealthy for longer than the policy allows,
+// within its rate limit, it updates the remediation status and returns when the pods should be checked again
+func (r *ReconcileVault) remediatePods(ctx context.Context, v *vaultv1alpha1.Vault) time.Duration {
+	policy := v.Spec.Remediation
+	now := time.Now()
+
+	previous := v.Status.Remediation
+	if previous == nil {
+		previous = &vaultv1alpha1.RemediationStatus{}
+	}
+
+	// Remediations older than the window don't count against the rate limit anymore
+	actions := slices.DeleteFunc(slices.Clone(previous.Actions), func(action vaultv1alpha1.RemediationRecord) bool {
+		return now.Sub(action.Time.Time) >= policy.GetWindow()
+	})
+
+	var next time.Duration
+	requeueAfter := func(d time.Duration) {
+		if next == 0 || d < next {
+			next = d
+		}
+	}
+
+	// allowed tells whether a pod can be remediated now, within the switch of the operator and the policy
+	allowed := func(podName, state string) bool {
+		switch {
+		case RemediationDisabled:
+			r.recorder.Eventf(v, corev1.EventTypeWarning, "RemediationSkipped",
+				"Pod %s is %s, remediation is disabled in the operator", podName, state)
+			return false
+		case policy.Paused:
+			r.recorder.Eventf(v, corev1.EventTypeWarning, "RemediationSkipped",
+				"Pod %s is %s, remediation is paused", podName, state)
+			return false
+		case len(actions) >= policy.GetMaxActions():
+			r.recorder.Eventf(v, corev1.EventTypeWarning, "RemediationRateLimited",
+				"Pod %s is %s, %d remediations were already taken within %s", podName, state, len(actions), policy.GetWindow())
+			requeueAfter(actions[0].Time.Add(policy.GetWindow()).Sub(now))
+			return false
+		}
+		return true
+	}
+
+	// The pods of a raft rejoin are recreated before their volume claims are gone
+	restarts, removedClaims, err := r.podsOfRemovedClaims(ctx, v, previous.RemovedClaims)
+	if err != nil {
+		r.recorder.Eventf(v, corev1.EventTypeWarning, "RemediationFailed", "%s failed: %v", vaultv1alpha1.RemediationRaftRejoin, err)
+	}
+	waiting := slices.ContainsFunc(removedClaims, func(claim vaultv1alpha1.RemovedClaim) bool {
+		return !slices.ContainsFunc(restarts, func(pod corev1.Pod) bool { return pod.Name == claim.Pod })
+	})
+	if waiting || err != nil {
+		requeueAfter(5 * time.Second)
+	}
+	var restarted []string
+	for _, pod := range restarts {
+		if !allowed(pod.Name, "running without its volume claims") {
+			continue
+		}
+		if err := r.client.Delete(ctx, &pod); err != nil && !apierrors.IsNotFound(err) {
+			r.recorder.Eventf(v, corev1.EventTypeWarning, "RemediationFailed", "%s of pod %s failed: failed to delete pod: %v",
+				vaultv1alpha1.RemediationRaftRejoin, pod.Name, err)
+			requeueAfter(5 * time.Second)
+			continue
+		}
+		actions = append(actions, vaultv1alpha1.RemediationRecord{
+			Pod: pod.Name, Action: vaultv1alpha1.RemediationRaftRejoin, Reason: podClaimsRemoved, Time: metav1.NewTime(now),
+		})
+		restarted = append(restarted, pod.Name)
+		r.recorder.Eventf(v, corev1.EventTypeNormal, "PodRemediated", "Restarting pod %s with new volume claims", pod.Name)
+		requeueAfter(5 * time.Second)
+	}
+
+	var unhealthy []vaultv1alpha1.UnhealthyPod
+	var active string
+	for i := 0; i < int(v.Spec.Size); i++ {
+		podName := fmt.Sprintf("%s-%d", v.Name, i)
+		reason, standby := r.podHealth(ctx, v, podName)
+		if reason == "" {
+			if !standby {
+				active = podName
+			}
+			continue
+		}
+
+		since := metav1.NewTime(now)
+		index := slices.IndexFunc(previous.UnhealthyPods, func(pod vaultv1alpha1.UnhealthyPod) bool { return pod.Name == podName })
+		switch {
+		case slices.Contains(restarted, podName):
+			// The restarted pod gets the same time to become healthy
+		case index >= 0 && previous.UnhealthyPods[index].Reason == reason:
+			since = previous.UnhealthyPods[index].Since
+		default:
+			r.recorder.Eventf(v, corev1.EventTypeWarning, "PodUnhealthy", "Pod %s is %s", podName, reason)
+		}
+		unhealthy = append(unhealthy, vaultv1alpha1.UnhealthyPod{Name: podName, Reason: reason, Since: since})
+	}
+
+	for i, pod := range unhealthy {
+		due := pod.Since.Add(policy.GetAfter())
+		if now.Before(due) {
+			requeueAfter(due.Sub(now))
+			continue
+		}
+
+		if !allowed(pod.Name, fmt.Sprintf("%s since %s", pod.Reason, pod.Since.UTC().Format(time.RFC3339))) {
+			continue
+		}
+
+		action := policy.GetAction()
+		claims, err := r.remediatePod(ctx, v, pod.Name, action, active)
+		// The claims deleted before a failure are recorded as well
+		removedClaims = append(removedClaims, claims...)
+		if err != nil {
+			r.recorder.Eventf(v, corev1.EventTypeWarning, "RemediationFailed", "%s of pod %s failed: %v", action, pod.Name, err)
+			requeueAfter(policy.GetAfter())
+			continue
+		}
+		if len(claims) > 0 {
+			requeueAfter(5 * time.Second)
+		}
+
+		actions = append(actions, vaultv1alpha1.RemediationRecord{Pod: pod.Name, Action: action, Reason: pod.Reason, Time: metav1.NewTime(now)})
+		r.recorder.Eventf(v, corev1.EventTypeNormal, "PodRemediated", "%s of pod %s, it was %s since %s",
+			action, pod.Name, pod.Reason, pod.Since.UTC().Format(time.RFC3339))
+
+		// The recreated pod gets the same time to become healthy
+		unhealthy[i].Since = metav1.NewTime(now)
+		requeueAfter(policy.GetAfter())
+	}
+
+	v.Status.Remediation = &vaultv1alpha1.RemediationStatus{UnhealthyPods: unhealthy, Actions: actions, RemovedClaims: removedClaims}
+	if len(unhealthy) == 0 && len(actions) == 0 && len(removedClaims) == 0 {
+		v.Status.Remediation = nil
+	}
+
+	return next
+}
+
+// podHealth returns why a Vault pod is unhealthy, or whether it is a standby if it is healthy
+func (r *ReconcileVault) podHealth(ctx context.Context, v *vaultv1alpha1.Vault, podName string) (string, bool) {
+	vaultClient, err := r.httpClients.vaultClientForPod(ctx, r.client, v, podName)
+	if err != nil {
+		return podUnreachable, false
+	}
+	// The next reconcile checks the pod again
+	vaultClient.SetMaxRetries(0)
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	health, err := vaultClient.Sys().HealthWithContext(ctx)
+	switch {
+	case err != nil:
+		return podUnreachable, false
+	case !health.Initialized:
+		return podUninitialized, false
+	case health.Sealed && v.Spec.UnsealConfig.Custodians == nil:
+		// Pods unsealed by custodians wait for their key shares, a restart doesn't help them
+		return podSealed, false
+	}
+	return "", health.Standby
+}
+
+// remediatePod takes the action of the remediation policy on an unhealthy pod, it returns the volume claims it deleted
+func (r *ReconcileVault) remediatePod(ctx context.Context, v *vaultv1alpha1.Vault, podName string, action vaultv1alpha1.RemediationAction, active string) ([]vaultv1alpha1.RemovedClaim, error) {
+	pod := corev1.Pod{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: podName}, &pod)
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("pod is not created yet")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get pod: %v", err)
+	}
+
+	var removedClaims []vaultv1alpha1.RemovedClaim
+	switch action {
+	case vaultv1alpha1.RemediationRaftRejoin:
+		if err := r.removeRaftPeer(ctx, v, podName, active); err != nil {
+			return nil, err
+		}
+
+		// The data of a removed peer can't be used to join the cluster again, the claims are only gone
+		// once the pod is deleted, the pod recreated meanwhile is restarted again once they are
+		for _, template := range v.Spec.VolumeClaimTemplates {
+			pvc := corev1.PersistentVolumeClaim{}
+			err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: template.Name + "-" + podName}, &pvc)
+			if apierrors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return removedClaims, fmt.Errorf("failed to get volume claim %s: %v", template.Name+"-"+podName, err)
+			}
+			if err := r.nonNamespacedClient.Delete(ctx, &pvc, client.Preconditions{UID: &pvc.UID}); err != nil && !apierrors.IsNotFound(err) {
+				return removedClaims, fmt.Errorf("failed to delete volume claim %s: %v", pvc.Name, err)
+			}
+			removedClaims = append(removedClaims, vaultv1alpha1.RemovedClaim{Pod: podName, Name: pvc.Name, UID: pvc.UID})
+		}
+
+	case vaultv1alpha1.RemediationCordon:
+		if pod.Spec.NodeName != "" {
+			node := corev1.Node{}
+			if err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, &node); err != nil {
+				return nil, fmt.Errorf("failed to get node %s: %v", pod.Spec.NodeName, err)
+			}
+			if !node.Spec.Unschedulable {
+				node.Spec.Unschedulable = true
+				if err := r.nonNamespacedClient.Update(ctx, &node); err != nil {
+					return nil, fmt.Errorf("failed to cordon node %s: %v", pod.Spec.NodeName, err)
+				}
+				r.recorder.Eventf(v, corev1.EventTypeNormal, "NodeCordoned", "Cordoned node %s of pod %s", node.Name, podName)
+			}
+		}
+	}
+
+	if err := r.client.Delete(ctx, &pod); err != nil && !apierrors.IsNotFound(err) {
+		return removedClaims, fmt.Errorf("failed to delete pod: %v", err)
+	}
+	return removedClaims, nil
+}
+
+// podsOfRemovedClaims returns the pods which were recreated while the volume claims deleted by a raft rejoin were still
+// terminating, the StatefulSet only creates the claims with the pods, and the claims still to wait for. The claims are read
+// uncached, a claim is done once the StatefulSet created a new one in its place.
+func (r *ReconcileVault) podsOfRemovedClaims(ctx context.Context, v *vaultv1alpha1.Vault, removedClaims []vaultv1alpha1.RemovedClaim) ([]corev1.Pod, []vaultv1alpha1.RemovedClaim, error) {
+	var restarts []corev1.Pod
+	var remaining []vaultv1alpha1.RemovedClaim
+
+	for _, podName := range sortedPodsOfRemovedClaims(removedClaims) {
+		claims := slices.DeleteFunc(slices.Clone(removedClaims), func(claim vaultv1alpha1.RemovedClaim) bool { return claim.Pod != podName })
+
+		// The pods removed by a scale down aren't recreated
+		if ordinal, ok := podOrdinal(v, podName); !ok || ordinal >= int(v.Spec.Size) {
+			continue
+		}
+
+		terminating, missing := false, false
+		for _, claim := range claims {
+			pvc := corev1.PersistentVolumeClaim{}
+			err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: claim.Name}, &pvc)
+			switch {
+			case apierrors.IsNotFound(err):
+				missing = true
+			case err != nil:
+				return nil, removedClaims, fmt.Errorf("failed to get volume claim %s: %v", claim.Name, err)
+			case pvc.UID == claim.UID:
+				terminating = true
+			}
+		}
+		if !missing && !terminating {
+			// The StatefulSet created new claims for the pod
+			continue
+		}
+		remaining = append(remaining, claims...)
+		if terminating {
+			continue
+		}
+
+		pod := corev1.Pod{}
+		err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: podName}, &pod)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, removedClaims, fmt.Errorf("failed to get pod %s: %v", podName, err)
+		}
+		if pod.DeletionTimestamp == nil {
+			restarts = append(restarts, pod)
+		}
+	}
+
+	return restarts, remaining, nil
+}
+
+// sortedPodsOfRemovedClaims returns the pods of the removed claims in order
+func sortedPodsOfRemovedClaims(removedClaims []vaultv1alpha1.RemovedClaim) []string {
+	var pods []string
+	for _, claim := range removedClaims {
+		if !slices.Contains(pods, claim.Pod) {
+			pods = append(pods, claim.Pod)
+		}
+	}
+	slices.Sort(pods)
+	return pods
+}
+
+// podOrdinal returns the ordinal of a pod of the Vault StatefulSet
+func podOrdinal(v *vaultv1alpha1.Vault, podName string) (int, bool) {
+	suffix, ok := strings.CutPrefix(podName, v.Name+"-")
+	if !ok {
+		return 0, false
+	}
+	ordinal, err := strconv.Atoi(suffix)
+	return ordinal, err == nil
+}
+
+// removeRaftPeer removes a pod from the Raft cluster through the active pod
+func (r *ReconcileVault) removeRaftPeer(ctx context.Context, v *vaultv1alpha1.Vault, podName, active string) error {
+	if !v.Spec.IsRaftStorage() {
+		return fmt.Errorf("vault doesn't use raft storage")
+	}
+	if active == "" {
+		return fmt.Errorf("no healthy active pod to remove the raft peer with")
+	}
+
+	adminClient, err := r.httpClients.adminClientForVault(ctx, r.nonNamespacedClient, v, podAddressForVault(v, active))
+	if err != nil {
+		return err
+	}
+
+	configuration, err := adminClient.Logical().ReadWithContext(ctx, "sys/storage/raft/configuration")
+	if err != nil {
+		return fmt.Errorf("failed to read raft configuration: %v", err)
+	}
+	if configuration == nil {
+		return fmt.Errorf("failed to read raft configuration: empty response")
+	}
+
+	// The peers advertise the pod DNS name as their cluster address
+	address := net.JoinHostPort(podFQDN(v, podName), clusterPort(v))
+	var nodeID string
+	for _, server := range cast.ToSlice(cast.ToStringMap(configuration.Data["config"])["servers"]) {
+		peer := cast.ToStringMap(server)
+		if cast.ToString(peer["address"]) == address || cast.ToString(peer["node_id"]) == podName {
+			nodeID = cast.ToString(peer["node_id"])
+		}
+	}
+	if nodeID == "" {
+		return nil
+	}
+
+	_, err = adminClient.Logical().WriteWithContext(ctx, "sys/storage/raft/remove-peer", map[string]interface{}{"server_id": nodeID})
+	if err != nil {
+		return fmt.Errorf("failed to remove raft peer %s: %v", nodeID, err)
+	}
+
+	r.recorder.Eventf(v, corev1.EventTypeNormal, "RaftPeerRemoved", "Removed pod %s from the raft cluster", podName)
+	return nil
+}
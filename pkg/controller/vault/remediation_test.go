@@ -0,0 +1,149 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRemediatePods(t *testing.T) {
+	since := metav1.NewTime(time.Now().Add(-20 * time.Minute))
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size:        2,
+			Remediation: &vaultv1alpha1.RemediationPolicy{Action: vaultv1alpha1.RemediationCordon},
+		},
+		Status: vaultv1alpha1.VaultStatus{
+			Remediation: &vaultv1alpha1.RemediationStatus{
+				UnhealthyPods: []vaultv1alpha1.UnhealthyPod{
+					{Name: "vault-0", Reason: podUnreachable, Since: since},
+					{Name: "vault-1", Reason: podUnreachable, Since: since},
+				},
+			},
+		},
+	}
+
+	pod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "vault"},
+			Spec:       corev1.PodSpec{NodeName: "node-1"},
+		}
+	}
+
+	r, c := newTestReconciler(t,
+		pod("vault-0"), pod("vault-1"), &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+	)
+
+	// The pods can't be reached, only one of them is remediated within the window
+	next := r.remediatePods(context.Background(), v)
+	assert.Greater(t, next, time.Duration(0))
+
+	node := corev1.Node{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "node-1"}, &node))
+	assert.True(t, node.Spec.Unschedulable)
+	assert.True(t, apierrors.IsNotFound(c.Get(context.Background(), client.ObjectKey{Namespace: "vault", Name: "vault-0"}, &corev1.Pod{})))
+	assert.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "vault", Name: "vault-1"}, &corev1.Pod{}))
+
+	require.Len(t, v.Status.Remediation.Actions, 1)
+	assert.Equal(t, "vault-0", v.Status.Remediation.Actions[0].Pod)
+	assert.Equal(t, vaultv1alpha1.RemediationCordon, v.Status.Remediation.Actions[0].Action)
+	require.Len(t, v.Status.Remediation.UnhealthyPods, 2)
+	assert.True(t, v.Status.Remediation.UnhealthyPods[0].Since.After(since.Time))
+	assert.Equal(t, since, v.Status.Remediation.UnhealthyPods[1].Since)
+
+	// The safety switch of the operator stops the remediation once the window is over
+	RemediationDisabled = true
+	defer func() { RemediationDisabled = false }()
+	v.Status.Remediation.Actions[0].Time = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	r.remediatePods(context.Background(), v)
+	assert.Empty(t, v.Status.Remediation.Actions)
+	assert.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "vault", Name: "vault-1"}, &corev1.Pod{}))
+}
+
+func TestRestartPodsOfRemovedClaims(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size:        2,
+			Remediation: &vaultv1alpha1.RemediationPolicy{Action: vaultv1alpha1.RemediationRaftRejoin, MaxActions: 3},
+			VolumeClaimTemplates: []vaultv1alpha1.EmbeddedPersistentVolumeClaim{
+				{EmbeddedObjectMetadata: vaultv1alpha1.EmbeddedObjectMetadata{Name: "vault-raft"}},
+			},
+		},
+		Status: vaultv1alpha1.VaultStatus{
+			Remediation: &vaultv1alpha1.RemediationStatus{
+				RemovedClaims: []vaultv1alpha1.RemovedClaim{
+					{Pod: "vault-0", Name: "vault-raft-vault-0", UID: "claim-0"},
+					{Pod: "vault-1", Name: "vault-raft-vault-1", UID: "claim-1"},
+				},
+			},
+		},
+	}
+
+	pod := func(name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "vault"}}
+	}
+	pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+		Name:       "vault-raft-vault-0",
+		Namespace:  "vault",
+		UID:        "claim-0",
+		Finalizers: []string{"kubernetes.io/pvc-protection"},
+	}}
+
+	ctx := context.Background()
+	r, c := newTestReconciler(t, pod("vault-0"), pod("vault-1"), pod("vault-2"), pvc)
+	require.NoError(t, c.Delete(ctx, pvc))
+
+	// The safety switch of the operator keeps the pods of the removed claims as well
+	RemediationDisabled = true
+	r.remediatePods(ctx, v)
+	RemediationDisabled = false
+	assert.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "vault", Name: "vault-1"}, &corev1.Pod{}))
+	assert.Len(t, v.Status.Remediation.RemovedClaims, 2)
+
+	// The pod of the removed claim is restarted, the other one waits for its claim to be gone
+	next := r.remediatePods(ctx, v)
+	assert.Equal(t, 5*time.Second, next)
+	assert.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "vault", Name: "vault-0"}, &corev1.Pod{}))
+	assert.True(t, apierrors.IsNotFound(c.Get(ctx, client.ObjectKey{Namespace: "vault", Name: "vault-1"}, &corev1.Pod{})))
+	require.Len(t, v.Status.Remediation.Actions, 1)
+	assert.Equal(t, podClaimsRemoved, v.Status.Remediation.Actions[0].Reason)
+
+	// Only the pods of the claims removed by the operator are restarted
+	assert.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "vault", Name: "vault-2"}, &corev1.Pod{}))
+
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(pvc), pvc))
+	pvc.Finalizers = nil
+	require.NoError(t, c.Update(ctx, pvc))
+	require.NoError(t, c.Create(ctx, &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+		Name: "vault-raft-vault-1", Namespace: "vault", UID: "new-claim-1",
+	}}))
+
+	r.remediatePods(ctx, v)
+	assert.True(t, apierrors.IsNotFound(c.Get(ctx, client.ObjectKey{Namespace: "vault", Name: "vault-0"}, &corev1.Pod{})))
+	require.Len(t, v.Status.Remediation.Actions, 2)
+	assert.Equal(t, []vaultv1alpha1.RemovedClaim{{Pod: "vault-0", Name: "vault-raft-vault-0", UID: "claim-0"}}, v.Status.Remediation.RemovedClaims)
+}
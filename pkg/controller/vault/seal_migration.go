@@ -0,0 +1,411 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	unsealKeyPrefix   = "vault-unseal-"
+	recoveryKeyPrefix = "vault-recovery-"
+)
+
+// sealForVault returns the seal described by the Vault spec, in the form recorded in the status
+func sealForVault(v *vaultv1alpha1.Vault) vaultv1alpha1.SealStatus {
+	seal := vaultv1alpha1.SealStatus{
+		Backend: v.Spec.UnsealConfig.Backend(),
+		Type:    v.Spec.GetSealType(),
+		Config:  v.Spec.GetRenderedSeal(),
+	}
+	if v.Spec.UnsealConfig.IsKubernetes() {
+		secretNamespace, secretName := v.Spec.UnsealConfig.KubernetesSecret(v)
+		seal.KeysSecret = secretNamespace + "/" + secretName
+	}
+	return seal
+}
+
+// startSealMigration starts a seal migration when the unseal backend of an initialised Vault changes,
+// it has to run before the Vault config and the StatefulSet are rendered, since both depend on it
+func (r *ReconcileVault) startSealMigration(ctx context.Context, v *vaultv1alpha1.Vault) error {
+	current := v.Status.Seal
+	if current == nil || !v.Spec.UnsealConfig.Options.SealMigration {
+		return nil
+	}
+
+	target := sealForVault(v)
+	migration := v.Status.SealMigration
+	failed := migration != nil && migration.Phase == vaultv1alpha1.SealMigrationFailed
+	if (migration.InProgress() || failed) && reflect.DeepEqual(migration.Target, target) {
+		return nil
+	}
+
+	if reflect.DeepEqual(*current, target) {
+		// Nothing to migrate, unless the spec was reverted after some pods had already been migrated
+		if failed || (migration.InProgress() && len(migration.MigratedPods) == 0) {
+			v.Status.SealMigration = nil
+			return r.client.Update(ctx, v)
+		}
+		if !migration.InProgress() {
+			return nil
+		}
+	}
+
+	now := metav1.Now()
+	migration = &vaultv1alpha1.SealMigrationStatus{
+		Phase:     vaultv1alpha1.SealMigrationRestarting,
+		Target:    target,
+		StartTime: &now,
+	}
+
+	if current.Backend != "kubernetes" || current.KeysSecret == "" {
+		migration.Phase = vaultv1alpha1.SealMigrationFailed
+		migration.Message = fmt.Sprintf("the operator can only read the keys from a Kubernetes Secret, they are stored by the %s backend", current.Backend)
+	} else if target.Backend != "kubernetes" || target.KeysSecret == "" {
+		// The unsealer of the new backend would find no keys, and the pods could no longer be unsealed
+		migration.Phase = vaultv1alpha1.SealMigrationFailed
+		migration.Message = fmt.Sprintf("the operator can only move the keys to a Kubernetes Secret, not to the %s backend", target.Backend)
+	} else if current.Type == target.Type && current.Config != nil && !reflect.DeepEqual(current.Config, target.Config) {
+		migration.Phase = vaultv1alpha1.SealMigrationFailed
+		migration.Message = fmt.Sprintf("migrating between two %s seals is not supported", target.Type)
+	}
+
+	if migration.Phase == vaultv1alpha1.SealMigrationFailed {
+		r.recorder.Event(v, corev1.EventTypeWarning, "SealMigrationFailed", migration.Message)
+	} else {
+		r.recorder.Eventf(v, corev1.EventTypeNormal, "SealMigrationStarted", "Migrating from the %s backend with the %s seal to the %s backend with the %s seal",
+			current.Backend, current.Type, target.Backend, target.Type)
+	}
+
+	v.Status.SealMigration = migration
+	if err := r.client.Update(ctx, v); err != nil {
+		return fmt.Errorf("failed to update seal migration status: %v", err)
+	}
+
+	return nil
+}
+
+// migrateSeal moves the Vault pods to the target seal one at a time, then moves the keys to the new backend
+func (r *ReconcileVault) migrateSeal(ctx context.Context, v *vaultv1alpha1.Vault, leader string) error {
+	migration := v.Status.SealMigration
+
+	switch migration.Phase {
+	case vaultv1alpha1.SealMigrationRestarting:
+		done, err := r.restartPodForSealMigration(ctx, v, leader)
+		if err != nil || !done {
+			return err
+		}
+		migration.Phase = vaultv1alpha1.SealMigrationMovingKeys
+		migration.Message = ""
+		fallthrough
+	case vaultv1alpha1.SealMigrationMovingKeys:
+		return r.completeSealMigration(ctx, v, leader)
+	}
+
+	return nil
+}
+
+// sealMigrationOrder returns the Vault pods in the order of the migration, the standby pods first and the active one last
+func sealMigrationOrder(v *vaultv1alpha1.Vault, leader string) []string {
+	podNames := make([]string, 0, v.Spec.Size)
+	for i := 0; i < int(v.Spec.Size); i++ {
+		if podName := fmt.Sprintf("%s-%d", v.Name, i); podName != leader {
+			podNames = append(podNames, podName)
+		}
+	}
+	if leader != "" {
+		podNames = append(podNames, leader)
+	}
+	return podNames
+}
+
+// restartPodForSealMigration deletes the next pod still running with the previous pod template, so the StatefulSet
+// recreates it with the new seal, and unseals it in migration mode, it returns true once every pod has been migrated
+func (r *ReconcileVault) restartPodForSealMigration(ctx context.Context, v *vaultv1alpha1.Vault, leader string) (bool, error) {
+	migration := v.Status.SealMigration
+
+	statefulSet := appsv1.StatefulSet{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: v.Name}, &statefulSet)
+	if err != nil {
+		return false, fmt.Errorf("failed to get StatefulSet: %v", err)
+	}
+
+	// The update revision is only known once the StatefulSet controller has seen the new pod template
+	if statefulSet.Status.ObservedGeneration < statefulSet.Generation {
+		migration.Message = "waiting for the StatefulSet to observe the new pod template"
+		return false, nil
+	}
+
+	for _, podName := range sealMigrationOrder(v, leader) {
+		if slices.Contains(migration.MigratedPods, podName) {
+			continue
+		}
+
+		pod := corev1.Pod{}
+		err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: podName}, &pod)
+		if apierrors.IsNotFound(err) {
+			migration.Message = fmt.Sprintf("waiting for pod %s to be recreated", podName)
+			return false, nil
+		} else if err != nil {
+			return false, fmt.Errorf("failed to get pod %s: %v", podName, err)
+		}
+
+		if pod.Labels[appsv1.ControllerRevisionHashLabelKey] != statefulSet.Status.UpdateRevision {
+			if pod.DeletionTimestamp == nil {
+				err := r.client.Delete(ctx, &pod)
+				if err != nil && !apierrors.IsNotFound(err) {
+					return false, fmt.Errorf("failed to delete pod %s: %v", podName, err)
+				}
+				r.recorder.Eventf(v, corev1.EventTypeNormal, "SealMigration", "Restarting pod %s with the %s seal", podName, migration.Target.Type)
+			}
+			migration.Message = fmt.Sprintf("restarting pod %s", podName)
+			return false, nil
+		}
+
+		err = r.unsealForSealMigration(ctx, v, podName)
+		if err != nil {
+			return false, err
+		}
+
+		// One pod per reconcile, so the status records every step
+		migration.MigratedPods = append(migration.MigratedPods, podName)
+		migration.Message = fmt.Sprintf("pod %s migrated", podName)
+		r.recorder.Eventf(v, corev1.EventTypeNormal, "SealMigration", "Pod %s unsealed with the %s seal", podName, migration.Target.Type)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// unsealForSealMigration unseals a restarted pod with the keys of the previous seal,
+// in migration mode if Vault found both the previous and the new seal in its config
+func (r *ReconcileVault) unsealForSealMigration(ctx context.Context, v *vaultv1alpha1.Vault, podName string) error {
+	vaultClient, err := r.httpClients.vaultClientForPod(ctx, r.client, v, podName)
+	if err != nil {
+		return err
+	}
+
+	sealStatus, err := vaultClient.Sys().SealStatusWithContext(ctx)
+	if err != nil {
+		return fmt.Errorf("waiting for pod %s to respond: %v", podName, err)
+	}
+	if !sealStatus.Sealed {
+		return nil
+	}
+
+	keys, err := r.sealMigrationKeys(ctx, v)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		sealStatus, err = vaultClient.Sys().UnsealWithOptionsWithContext(ctx, &api.UnsealOpts{Key: key, Migrate: sealStatus.Migration})
+		if err != nil {
+			return fmt.Errorf("failed to unseal pod %s: %v", podName, err)
+		}
+		if !sealStatus.Sealed {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("pod %s is still sealed after submitting %d keys", podName, len(keys))
+}
+
+// sealMigrationKeys returns the unseal keys of a Shamir seal, or the recovery keys of an auto seal,
+// from the Secret of the previous unseal backend
+func (r *ReconcileVault) sealMigrationKeys(ctx context.Context, v *vaultv1alpha1.Vault) ([]string, error) {
+	secret, err := r.sealKeysSecret(ctx, v.Status.Seal.KeysSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := keyPrefix(v.Status.Seal.Type)
+	keys := storedKeys(secret, prefix)
+
+	// Recovery keys may be kept in their own Secret
+	if len(keys) == 0 && prefix == recoveryKeyPrefix {
+		recoveryNamespace, recoveryName := v.Spec.UnsealConfig.RecoveryKeysSecret(v)
+		recoverySecret, err := r.sealKeysSecret(ctx, recoveryNamespace+"/"+recoveryName)
+		if err != nil {
+			return nil, err
+		}
+		keys = storedKeys(recoverySecret, prefix)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no %s* keys found in secret %s", prefix, v.Status.Seal.KeysSecret)
+	}
+
+	return r.openKeys(ctx, v, keys)
+}
+
+// keyPrefix returns the prefix of the stored unseal keys of a Shamir seal, or of the recovery keys of an auto seal
+func keyPrefix(sealType string) string {
+	if sealType == vaultv1alpha1.SealTypeShamir {
+		return unsealKeyPrefix
+	}
+	return recoveryKeyPrefix
+}
+
+// storedKeys returns the keys with the given prefix in the order of their index
+func storedKeys(secret *corev1.Secret, prefix string) []string {
+	var keys []string
+	for i := 0; ; i++ {
+		key, ok := secret.Data[fmt.Sprint(prefix, i)]
+		if !ok {
+			return keys
+		}
+		keys = append(keys, string(key))
+	}
+}
+
+func (r *ReconcileVault) sealKeysSecret(ctx context.Context, namespacedName string) (*corev1.Secret, error) {
+	secretNamespace, secretName, _ := strings.Cut(namespacedName, "/")
+	secret := corev1.Secret{}
+	err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: secretNamespace, Name: secretName}, &secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unseal keys secret: %v", err)
+	}
+	return &secret, nil
+}
+
+// completeSealMigration waits for Vault to finish the migration, which happens when the active pod
+// runs with the new seal, then moves the keys and records the new seal
+func (r *ReconcileVault) completeSealMigration(ctx context.Context, v *vaultv1alpha1.Vault, leader string) error {
+	migration := v.Status.SealMigration
+	if leader == "" {
+		return fmt.Errorf("waiting for an active Vault pod")
+	}
+
+	vaultClient, err := r.httpClients.vaultClientForPod(ctx, r.client, v, leader)
+	if err != nil {
+		return err
+	}
+
+	sealStatus, err := vaultClient.Sys().SealStatusWithContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get seal status of pod %s: %v", leader, err)
+	}
+	if sealStatus.Migration || sealStatus.Type != migration.Target.Type {
+		return fmt.Errorf("waiting for Vault to finish the migration to the %s seal", migration.Target.Type)
+	}
+
+	if err := r.moveSealKeys(ctx, v); err != nil {
+		return err
+	}
+
+	target := migration.Target
+	v.Status.Seal = &target
+	migration.Phase = vaultv1alpha1.SealMigrationCompleted
+	r.recorder.Eventf(v, corev1.EventTypeNormal, "SealMigrationCompleted", "Migrated to the %s backend with the %s seal", target.Backend, target.Type)
+
+	return nil
+}
+
+// moveSealKeys copies the keys and the root token to the Secret of the new Kubernetes unseal backend, unseal keys
+// of a Shamir seal become the recovery keys of an auto seal and the other way around
+func (r *ReconcileVault) moveSealKeys(ctx context.Context, v *vaultv1alpha1.Vault) error {
+	from, to := v.Status.Seal, v.Status.SealMigration.Target
+
+	source, err := r.sealKeysSecret(ctx, from.KeysSecret)
+	if err != nil {
+		return err
+	}
+
+	data := map[string][]byte{}
+	for key, value := range source.Data {
+		data[sealKeyName(key, from.Type, to.Type)] = value
+	}
+
+	// Recovery keys may be kept in their own Secret
+	if from.Type != vaultv1alpha1.SealTypeShamir {
+		recoveryNamespace, recoveryName := v.Spec.UnsealConfig.RecoveryKeysSecret(v)
+		recoverySecret := corev1.Secret{}
+		err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: recoveryNamespace, Name: recoveryName}, &recoverySecret)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get recovery keys secret: %v", err)
+		}
+		for key, value := range recoverySecret.Data {
+			if strings.HasPrefix(key, recoveryKeyPrefix) {
+				data[sealKeyName(key, from.Type, to.Type)] = value
+			}
+		}
+	}
+
+	if to.KeysSecret == from.KeysSecret {
+		source.Data = data
+		if err := r.nonNamespacedClient.Update(ctx, source); err != nil {
+			return fmt.Errorf("failed to update unseal keys secret: %v", err)
+		}
+		return nil
+	}
+
+	secretNamespace, secretName, _ := strings.Cut(to.KeysSecret, "/")
+	destination := corev1.Secret{}
+	err = r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: secretNamespace, Name: secretName}, &destination)
+	if apierrors.IsNotFound(err) {
+		destination = corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: secretNamespace, Labels: v.LabelsForVault()},
+			Data:       data,
+		}
+		if err := r.nonNamespacedClient.Create(ctx, &destination); err != nil {
+			return fmt.Errorf("failed to create unseal keys secret: %v", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to get unseal keys secret: %v", err)
+	} else {
+		if destination.Data == nil {
+			destination.Data = map[string][]byte{}
+		}
+		for key, value := range data {
+			destination.Data[key] = value
+		}
+		if err := r.nonNamespacedClient.Update(ctx, &destination); err != nil {
+			return fmt.Errorf("failed to update unseal keys secret: %v", err)
+		}
+	}
+
+	// The previous Secret is left in place, so the keys are never lost halfway
+	v.Status.SealMigration.Message = fmt.Sprintf("keys copied from secret %s to secret %s", from.KeysSecret, to.KeysSecret)
+
+	return nil
+}
+
+// sealKeyName renames the unseal keys of a Shamir seal to the recovery keys of an auto seal, and back
+func sealKeyName(key, fromType, toType string) string {
+	switch {
+	case fromType == vaultv1alpha1.SealTypeShamir && toType != vaultv1alpha1.SealTypeShamir:
+		if index, ok := strings.CutPrefix(key, unsealKeyPrefix); ok {
+			return recoveryKeyPrefix + index
+		}
+	case fromType != vaultv1alpha1.SealTypeShamir && toType == vaultv1alpha1.SealTypeShamir:
+		if index, ok := strings.CutPrefix(key, recoveryKeyPrefix); ok {
+			return unsealKeyPrefix + index
+		}
+	}
+	return key
+}
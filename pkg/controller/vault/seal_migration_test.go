@@ -0,0 +1,107 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSealMigration(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size:   3,
+			Config: extv1beta1.JSON{Raw: []byte(`{"storage": {"raft": {}}}`)},
+			UnsealConfig: vaultv1alpha1.UnsealConfig{
+				Options: vaultv1alpha1.UnsealOptions{SealMigration: true},
+			},
+		},
+	}
+	keys := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-unseal-keys", Namespace: "vault"},
+		Data: map[string][]byte{
+			"vault-unseal-0": []byte("key-0"),
+			"vault-unseal-1": []byte("key-1"),
+			"vault-root":     []byte("root"),
+		},
+	}
+
+	r, c := newTestReconciler(t, v, keys)
+
+	seal := sealForVault(v)
+	assert.Equal(t, vaultv1alpha1.SealStatus{Backend: "kubernetes", KeysSecret: "vault/vault-unseal-keys", Type: vaultv1alpha1.SealTypeShamir}, seal)
+	v.Status.Seal = &seal
+
+	// The keys can't be moved to the AWS backend, the unsealer would find none there
+	v.Spec.UnsealConfig.AWS = &vaultv1alpha1.AWSUnsealConfig{KMSKeyID: "alias/vault"}
+	require.NoError(t, r.startSealMigration(context.Background(), v))
+	assert.Equal(t, vaultv1alpha1.SealMigrationFailed, v.Status.SealMigration.Phase)
+	assert.Equal(t, "the operator can only move the keys to a Kubernetes Secret, not to the aws backend", v.Status.SealMigration.Message)
+	assert.False(t, v.Status.SealMigration.InProgress())
+
+	// A failed migration holds back nothing
+	statefulSet, err := statefulSetForVault(v, nil, map[string]string{}, serviceForVault(v))
+	require.NoError(t, err)
+	assert.Equal(t, appsv1.RollingUpdateStatefulSetStrategyType, statefulSet.Spec.UpdateStrategy.Type)
+
+	// Reverting the spec clears the failed migration
+	v.Spec.UnsealConfig.AWS = nil
+	require.NoError(t, r.startSealMigration(context.Background(), v))
+	assert.Nil(t, v.Status.SealMigration)
+
+	// Switching to the AWS KMS seal with the keys kept in Kubernetes starts a migration to the awskms seal
+	v.Spec.Config = extv1beta1.JSON{Raw: []byte(`{"storage": {"raft": {}}, "seal": {"awskms": {"kms_key_id": "alias/vault"}}}`)}
+	require.NoError(t, r.startSealMigration(context.Background(), v))
+	require.True(t, v.Status.SealMigration.InProgress())
+	assert.Equal(t, vaultv1alpha1.SealMigrationRestarting, v.Status.SealMigration.Phase)
+	assert.Equal(t, "awskms", v.Status.SealMigration.Target.Type)
+
+	// Standby pods are migrated first
+	assert.Equal(t, []string{"vault-0", "vault-2", "vault-1"}, sealMigrationOrder(v, "vault-1"))
+
+	statefulSet, err = statefulSetForVault(v, nil, map[string]string{}, serviceForVault(v))
+	require.NoError(t, err)
+	assert.Equal(t, appsv1.OnDeleteStatefulSetStrategyType, statefulSet.Spec.UpdateStrategy.Type)
+
+	// Unseal keys of the Shamir seal become the recovery keys of the auto seal
+	v.Spec.UnsealConfig.Kubernetes.SecretName = "vault-keys"
+	v.Status.SealMigration.Target = sealForVault(v)
+	v.Status.SealMigration.Target.Type = "awskms"
+	require.NoError(t, r.moveSealKeys(context.Background(), v))
+
+	moved := corev1.Secret{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "vault", Name: "vault-keys"}, &moved))
+	assert.Equal(t, map[string][]byte{
+		"vault-recovery-0": []byte("key-0"),
+		"vault-recovery-1": []byte("key-1"),
+		"vault-root":       []byte("root"),
+	}, moved.Data)
+
+	// Keys stored by other backends can't be read by the operator
+	v.Status.Seal.Backend = "google"
+	v.Status.SealMigration = nil
+	require.NoError(t, r.startSealMigration(context.Background(), v))
+	assert.Equal(t, vaultv1alpha1.SealMigrationFailed, v.Status.SealMigration.Phase)
+}
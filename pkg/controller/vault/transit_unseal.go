@@ -0,0 +1,317 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	"github.com/spf13/cast"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// transitKeyAnnotation records the transit key the token of the transit unseal Secret was created for
+const transitKeyAnnotation = "vault.banzaicloud.io/transit-key"
+
+// transitUnsealFinalizer makes sure the transit unseal token and policy are removed from the referenced Vault
+// before the Vault is gone
+const transitUnsealFinalizer = "vault.banzaicloud.com/transit-unseal-cleanup"
+
+// transitSealForVault renders the transit seal stanza of UnsealConfig.VaultRef, and prepares the referenced Vault
+// and the token of the seal when needed, an error means the Vault has to wait for the referenced one
+func (r *ReconcileVault) transitSealForVault(ctx context.Context, v *vaultv1alpha1.Vault) (map[string]interface{}, error) {
+	refNamespace, refName := v.TransitUnsealVault()
+	ref := &vaultv1alpha1.Vault{}
+	if err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: refNamespace, Name: refName}, ref); err != nil {
+		return nil, fmt.Errorf("failed to get referenced vault %s/%s: %v", refNamespace, refName, err)
+	}
+	if !ref.AllowsTransitUnsealFrom(v.Namespace) {
+		return nil, fmt.Errorf("referenced vault %s/%s doesn't list namespace %s in transitUnsealNamespaces", refNamespace, refName, v.Namespace)
+	}
+
+	mountPath := v.TransitUnsealMountPath()
+	keyName := v.TransitUnsealKeyName()
+	transitKey := refNamespace + "/" + refName + "/" + mountPath + "keys/" + keyName
+
+	current := corev1.Secret{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: v.TransitUnsealSecretName()}, &current)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get transit unseal secret: %v", err)
+	}
+
+	// The referenced Vault is only needed to create the token, which is kept afterwards
+	token := current.Data["token"]
+	if len(token) == 0 || current.Annotations[transitKeyAnnotation] != transitKey {
+		// The token created for another transit key is of no use anymore
+		if err := r.revokeTransitUnsealToken(ctx, v, &current); err != nil {
+			return nil, err
+		}
+		token, err = r.prepareTransitUnseal(ctx, v, ref)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        v.TransitUnsealSecretName(),
+			Namespace:   v.Namespace,
+			Labels:      v.LabelsForVault(),
+			Annotations: map[string]string{transitKeyAnnotation: transitKey},
+		},
+		Data: map[string][]byte{"token": token},
+	}
+
+	seal := map[string]interface{}{
+		"address":    strings.ToLower(string(getVaultURIScheme(ref))) + "://" + net.JoinHostPort(serviceFQDN(ref.Name, ref.Namespace), apiPort(ref)),
+		"key_name":   keyName,
+		"mount_path": mountPath,
+	}
+
+	if !ref.Spec.IsTLSDisabled() {
+		tlsSecretName := ref.Name + "-tls"
+		if ref.Spec.ExistingTLSSecretName != "" {
+			tlsSecretName = ref.Spec.ExistingTLSSecretName
+		}
+		tlsSecret := corev1.Secret{}
+		if err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: tlsSecretName}, &tlsSecret); err != nil {
+			return nil, fmt.Errorf("failed to get tls secret of referenced vault: %v", err)
+		}
+		caCertificate := tlsSecret.Data["ca.crt"]
+		if len(caCertificate) == 0 {
+			return nil, fmt.Errorf("CA certificate is missing from tls secret %s/%s", ref.Namespace, tlsSecretName)
+		}
+		secret.Data["ca.crt"] = caCertificate
+		seal["tls_ca_cert"] = vaultv1alpha1.VaultRefUnsealMountPath + "/ca.crt"
+	}
+
+	// Set Vault instance as the owner and controller
+	if err := controllerutil.SetControllerReference(v, secret, r.scheme); err != nil {
+		return nil, err
+	}
+	if err := r.createOrUpdateObject(ctx, secret); err != nil {
+		return nil, fmt.Errorf("failed to create/update transit unseal secret: %v", err)
+	}
+
+	return seal, nil
+}
+
+// prepareTransitUnseal enables the transit secret engine and the key on the referenced Vault, and returns
+// a new token which may only encrypt and decrypt with that key
+func (r *ReconcileVault) prepareTransitUnseal(ctx context.Context, v *vaultv1alpha1.Vault, ref *vaultv1alpha1.Vault) ([]byte, error) {
+	healthy := ref.Status.GetCondition(corev1.ComponentHealthy)
+	if healthy == nil || healthy.Status != corev1.ConditionTrue || ref.Status.Leader == "" {
+		return nil, fmt.Errorf("referenced vault %s/%s is not healthy yet", ref.Namespace, ref.Name)
+	}
+
+	vaultClient, err := r.httpClients.adminClientForVault(ctx, r.nonNamespacedClient, ref, podAddressForVault(ref, ref.Status.Leader))
+	if err != nil {
+		return nil, err
+	}
+
+	mountPath := v.TransitUnsealMountPath()
+	mounts, err := vaultClient.Sys().ListMountsWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secret engines of referenced vault: %v", err)
+	}
+	if _, ok := mounts[mountPath]; !ok {
+		if err := vaultClient.Sys().MountWithContext(ctx, mountPath, &api.MountInput{Type: "transit"}); err != nil {
+			return nil, fmt.Errorf("failed to enable transit secret engine on referenced vault: %v", err)
+		}
+	}
+
+	keyName := v.TransitUnsealKeyName()
+	key, err := vaultClient.Logical().ReadWithContext(ctx, mountPath+"keys/"+keyName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transit key %s: %v", keyName, err)
+	}
+	if key == nil {
+		if _, err := vaultClient.Logical().WriteWithContext(ctx, mountPath+"keys/"+keyName, nil); err != nil {
+			return nil, fmt.Errorf("failed to create transit key %s: %v", keyName, err)
+		}
+	}
+
+	policyName := transitUnsealPolicyName(v)
+	if err := vaultClient.Sys().PutPolicyWithContext(ctx, policyName, transitUnsealPolicy(mountPath, keyName)); err != nil {
+		return nil, fmt.Errorf("failed to write transit unseal policy: %v", err)
+	}
+
+	// An orphan token outlives the root token rotations of the referenced Vault, and the seal renews it
+	secret, err := vaultClient.Auth().Token().CreateOrphanWithContext(ctx, &api.TokenCreateRequest{
+		Policies:        []string{policyName},
+		Period:          "24h",
+		NoDefaultPolicy: true,
+		DisplayName:     policyName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transit unseal token: %v", err)
+	}
+	if secret == nil || secret.Auth == nil {
+		return nil, fmt.Errorf("failed to create transit unseal token: no token returned")
+	}
+
+	r.recorder.Eventf(v, corev1.EventTypeNormal, "TransitUnsealPrepared",
+		"Prepared transit key %s on vault %s/%s for auto-unseal", keyName, ref.Namespace, ref.Name)
+	return []byte(secret.Auth.ClientToken), nil
+}
+
+// releaseTransitUnseal revokes the transit unseal token of the Vault and deletes its policy on the referenced Vault
+func (r *ReconcileVault) releaseTransitUnseal(ctx context.Context, v *vaultv1alpha1.Vault) error {
+	secret := corev1.Secret{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: v.TransitUnsealSecretName()}, &secret)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get transit unseal secret: %v", err)
+	}
+	return r.revokeTransitUnsealToken(ctx, v, &secret)
+}
+
+// revokeTransitUnsealToken revokes the token of the transit unseal Secret and deletes its policy on the Vault
+// it was created on, there is nothing to revoke if that Vault is gone
+func (r *ReconcileVault) revokeTransitUnsealToken(ctx context.Context, v *vaultv1alpha1.Vault, secret *corev1.Secret) error {
+	token := secret.Data["token"]
+	refNamespace, refName, ok := transitKeyVault(secret.Annotations[transitKeyAnnotation])
+	if len(token) == 0 || !ok {
+		return nil
+	}
+
+	ref := &vaultv1alpha1.Vault{}
+	err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: refNamespace, Name: refName}, ref)
+	if apierrors.IsNotFound(err) || err == nil && !ref.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get referenced vault %s/%s: %v", refNamespace, refName, err)
+	}
+	if ref.Status.Leader == "" {
+		return fmt.Errorf("referenced vault %s/%s has no leader to revoke the transit unseal token", refNamespace, refName)
+	}
+
+	vaultClient, err := r.httpClients.adminClientForVault(ctx, r.nonNamespacedClient, ref, podAddressForVault(ref, ref.Status.Leader))
+	if err != nil {
+		return err
+	}
+	if err := vaultClient.Auth().Token().RevokeTreeWithContext(ctx, string(token)); err != nil {
+		return fmt.Errorf("failed to revoke transit unseal token: %v", err)
+	}
+	if err := vaultClient.Sys().DeletePolicyWithContext(ctx, transitUnsealPolicyName(v)); err != nil {
+		return fmt.Errorf("failed to delete transit unseal policy: %v", err)
+	}
+
+	r.recorder.Eventf(v, corev1.EventTypeNormal, "TransitUnsealReleased",
+		"Revoked the transit unseal token on vault %s/%s", refNamespace, refName)
+	return nil
+}
+
+// transitUnsealVaultsFor returns the Vaults which auto-unseal with the transit secret engine of the Vault,
+// and the ones which stopped auto-unsealing and may still have a transit unseal token to revoke
+func (r *ReconcileVault) transitUnsealVaultsFor(ctx context.Context, v *vaultv1alpha1.Vault) ([]vaultv1alpha1.Vault, error) {
+	vaults := vaultv1alpha1.VaultList{}
+	if err := r.client.List(ctx, &vaults); err != nil {
+		return nil, fmt.Errorf("failed to list vaults: %v", err)
+	}
+
+	var transitUnsealVaults []vaultv1alpha1.Vault
+	for _, other := range vaults.Items {
+		if !v.AllowsTransitUnsealFrom(other.Namespace) {
+			continue
+		}
+		if other.Spec.UnsealConfig.VaultRef == nil {
+			if controllerutil.ContainsFinalizer(&other, transitUnsealFinalizer) {
+				transitUnsealVaults = append(transitUnsealVaults, other)
+			}
+			continue
+		}
+		if refNamespace, refName := other.TransitUnsealVault(); refNamespace == v.Namespace && refName == v.Name {
+			transitUnsealVaults = append(transitUnsealVaults, other)
+		}
+	}
+
+	return transitUnsealVaults, nil
+}
+
+// transitUnsealVaultForVault enqueues the Vault referenced for transit unseal, so its operator identity
+// is allowed to prepare the transit key
+func transitUnsealVaultForVault(_ context.Context, v *vaultv1alpha1.Vault) []reconcile.Request {
+	if v.Spec.UnsealConfig.VaultRef == nil {
+		return nil
+	}
+	refNamespace, refName := v.TransitUnsealVault()
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: refNamespace, Name: refName}}}
+}
+
+// transitKeyVault returns the namespace and name of the Vault in a transit key recorded by transitKeyAnnotation
+func transitKeyVault(transitKey string) (string, string, bool) {
+	parts := strings.SplitN(transitKey, "/", 3)
+	if len(parts) < 3 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// transitUnsealPolicyName returns the name of the policy of the transit unseal token on the referenced Vault
+func transitUnsealPolicyName(v *vaultv1alpha1.Vault) string {
+	return "transit-unseal-" + v.Namespace + "-" + v.Name
+}
+
+// transitUnsealPolicy returns the policy allowing the transit seal to use its key and nothing else
+func transitUnsealPolicy(mountPath, keyName string) string {
+	return fmt.Sprintf(`path "%[1]sencrypt/%[2]s" {
+  capabilities = ["update"]
+}
+
+path "%[1]sdecrypt/%[2]s" {
+  capabilities = ["update"]
+}
+`, mountPath, keyName)
+}
+
+// withTransitSeal adds the transit seal rendered from UnsealConfig.VaultRef, unless the config has a seal stanza
+func withTransitSeal(v *vaultv1alpha1.Vault, configJSON []byte, transitSeal map[string]interface{}) ([]byte, error) {
+	if transitSeal == nil {
+		return configJSON, nil
+	}
+	if _, ok := v.Spec.GetVaultConfig()["seal"]; ok {
+		return configJSON, nil
+	}
+
+	config := map[string]interface{}{}
+	if err := json.Unmarshal(configJSON, &config); err != nil {
+		return nil, err
+	}
+
+	// A seal migration may have added the previous seal already
+	seals := cast.ToStringMap(config["seal"])
+	if seals == nil {
+		seals = map[string]interface{}{}
+	}
+	seals["transit"] = transitSeal
+	config["seal"] = seals
+
+	return json.Marshal(config)
+}
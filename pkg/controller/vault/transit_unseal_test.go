@@ -0,0 +1,107 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestTransitSealForVault(t *testing.T) {
+	ref := &vaultv1alpha1.Vault{ObjectMeta: metav1.ObjectMeta{Name: "unsealer", Namespace: "unseal"}}
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault", UID: "vault-uid"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Config: extv1beta1.JSON{Raw: []byte(`{"storage": {"file": {"path": "/vault/file"}}}`)},
+			UnsealConfig: vaultv1alpha1.UnsealConfig{
+				VaultRef: &vaultv1alpha1.VaultRefUnsealConfig{Name: "unsealer", Namespace: "unseal"},
+			},
+		},
+	}
+	assert.True(t, v.Spec.IsAutoUnseal())
+	assert.Equal(t, "transit", v.Spec.GetSealType())
+	assert.Nil(t, v.Spec.GetRenderedSeal())
+
+	r, c := newTestReconciler(t,
+		ref,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "unsealer-tls", Namespace: "unseal"},
+			Data:       map[string][]byte{"ca.crt": []byte("unsealer-ca")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "vault-transit-unseal",
+				Namespace:   "vault",
+				Annotations: map[string]string{transitKeyAnnotation: "unseal/unsealer/transit/keys/vault-vault"},
+			},
+			Data: map[string][]byte{"token": []byte("hvs.transit")},
+		},
+	)
+
+	// The referenced Vault has to allow the namespace
+	_, err := r.transitSealForVault(context.Background(), v)
+	assert.ErrorContains(t, err, "doesn't list namespace vault in transitUnsealNamespaces")
+	ref.Spec.TransitUnsealNamespaces = []string{"vault"}
+	require.NoError(t, c.Update(context.Background(), ref))
+
+	// The token is kept, the referenced Vault isn't needed
+	seal, err := r.transitSealForVault(context.Background(), v)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"address":     "https://unsealer.unseal.svc.cluster.local:8200",
+		"key_name":    "vault-vault",
+		"mount_path":  "transit/",
+		"tls_ca_cert": vaultv1alpha1.VaultRefUnsealMountPath + "/ca.crt",
+	}, seal)
+
+	secret := corev1.Secret{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "vault", Name: "vault-transit-unseal"}, &secret))
+	assert.Equal(t, map[string][]byte{"token": []byte("hvs.transit"), "ca.crt": []byte("unsealer-ca")}, secret.Data)
+
+	rawConfig, _, err := secretForRawVaultConfig(v, seal)
+	require.NoError(t, err)
+	config := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(rawConfig.Data["vault-config.json"], &config))
+	assert.Equal(t, map[string]interface{}{"transit": seal}, config["seal"])
+
+	envs := withSealEnv(v, nil)
+	require.Len(t, envs, 1)
+	assert.Equal(t, "vault-transit-unseal", envs[0].ValueFrom.SecretKeyRef.Name)
+
+	// A new key needs the referenced Vault to revoke the previous token, which has no leader yet
+	v.Spec.UnsealConfig.VaultRef.KeyName = "other"
+	_, err = r.transitSealForVault(context.Background(), v)
+	assert.ErrorContains(t, err, "has no leader")
+
+	// There is nothing to revoke once the referenced Vault is gone, the Vault is let go
+	require.NoError(t, c.Delete(context.Background(), ref))
+	v.Finalizers = []string{transitUnsealFinalizer}
+	require.NoError(t, c.Create(context.Background(), v))
+	require.NoError(t, c.Delete(context.Background(), v))
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(v), v))
+	_, err = r.finalizeVault(context.Background(), v)
+	require.NoError(t, err)
+	assert.True(t, apierrors.IsNotFound(c.Get(context.Background(), client.ObjectKeyFromObject(v), v)))
+}
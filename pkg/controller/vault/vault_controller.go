@@ -18,21 +18,22 @@ import (
 	"context"
 	"crypto/hmac"
 	"crypto/sha256"
-	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"net/http"
+	"net"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
 	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
 	bvtls "github.com/bank-vaults/vault-sdk/tls"
-	"github.com/bank-vaults/vault-sdk/vault"
 	"github.com/cisco-open/k8s-objectmatcher/patch"
 	"github.com/hashicorp/vault/api"
 	"github.com/imdario/mergo"
@@ -41,13 +42,17 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -61,9 +66,29 @@ import (
 
 const defaultConfigFile = "vault-config.yml"
 
+const (
+	// tlsExpirationAnnotation is the expiration date of the TLS certificate the Vault pods were last restarted for
+	tlsExpirationAnnotation = "vault.banzaicloud.io/tls-expiration-date"
+	// tlsPodIPsAnnotation lists the pod IPs the TLS certificate of the TLS Secret was generated with
+	tlsPodIPsAnnotation = "vault.banzaicloud.io/tls-pod-ips"
+)
+
 var (
 	log = logf.Log.WithName("controller_vault")
 
+	// ClusterDomain is the DNS domain of the Kubernetes cluster, used in the Service and pod DNS names
+	ClusterDomain = "cluster.local"
+
+	// RemediationDisabled turns off the remediation of unhealthy Vault pods for every Vault, they are only reported
+	RemediationDisabled bool
+
+	// OperatorNamespace is the namespace of the operator pods, the NetworkPolicies let the operator pods
+	// of any namespace reach Vault if it is unknown
+	OperatorNamespace string
+
+	// OperatorImage is the image of the operator, which runs the key decryptor sidecars of encrypted unseal keys
+	OperatorImage = "ghcr.io/bank-vaults/vault-operator:latest"
+
 	configFileNames = []string{"vault-config.yml", "vault-config.yaml"}
 )
 
@@ -87,7 +112,9 @@ func newReconciler(mgr manager.Manager) (reconcile.Reconciler, error) {
 		client:              mgr.GetClient(),
 		nonNamespacedClient: nonNamespacedClient,
 		scheme:              mgr.GetScheme(),
-		httpClient:          newHTTPClient(),
+		recorder:            mgr.GetEventRecorderFor("vault-operator"),
+		restConfig:          mgr.GetConfig(),
+		httpClients:         newVaultHTTPClientCache(),
 	}, nil
 }
 
@@ -105,6 +132,31 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch for changes to the Vaults auto-unsealing with a Vault, which has to allow the operator to prepare their keys
+	err = c.Watch(source.Kind(mgr.GetCache(), &vaultv1alpha1.Vault{}, handler.TypedEnqueueRequestsFromMapFunc(transitUnsealVaultForVault)))
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to Secrets selected by the watched Secrets selectors,
+	// so a rotated Secret restarts the Vault cluster without waiting for the next resync
+	err = c.Watch(source.Kind(mgr.GetCache(), &corev1.Secret{}, handler.TypedEnqueueRequestsFromMapFunc(vaultsForWatchedSecret(mgr.GetClient()))))
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the ConfigMaps and Secrets mounted into the configurer,
+	// so an edited external vault-config.yml rolls the configurer
+	err = c.Watch(source.Kind(mgr.GetCache(), &corev1.ConfigMap{}, handler.TypedEnqueueRequestsFromMapFunc(vaultForConfigurerObject[*corev1.ConfigMap])))
+	if err != nil {
+		return err
+	}
+
+	err = c.Watch(source.Kind(mgr.GetCache(), &corev1.Secret{}, handler.TypedEnqueueRequestsFromMapFunc(vaultForConfigurerObject[*corev1.Secret])))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -124,10 +176,20 @@ type ReconcileVault struct {
 	// TODO the cache should be restricted to Secrets only right now in this one if possible
 	nonNamespacedClient client.Client
 
-	scheme     *runtime.Scheme
-	httpClient *http.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+
+	// httpClients caches the HTTP clients of the Vaults and the tokens of the operator identity
+	httpClients *vaultHTTPClientCache
+
+	// restConfig is the Kubernetes client config of the operator, its host, CA and issuer configure
+	// the Kubernetes auth method of Vault
+	restConfig *rest.Config
 }
 
+// errStatefulSetRecreating is returned while a StatefulSet is deleted to be created again
+var errStatefulSetRecreating = errors.New("the StatefulSet is being recreated")
+
 func (r *ReconcileVault) createOrUpdateObject(ctx context.Context, o client.Object) error {
 	return createOrUpdateObjectWithClient(ctx, r.client, o)
 }
@@ -147,6 +209,20 @@ func createOrUpdateObjectWithClient(ctx context.Context, c client.Client, o clie
 	} else if err == nil {
 		// Handle special cases for update
 		switch o.(type) {
+		case *appsv1.StatefulSet:
+			// The governing Service of a StatefulSet is immutable, recreate it and leave the pods running,
+			// the orphaning delete finishes asynchronously, so it is created again on a later reconcile
+			currentSts := current.(*appsv1.StatefulSet)
+			if !currentSts.DeletionTimestamp.IsZero() {
+				return errStatefulSetRecreating
+			}
+			if currentSts.Spec.ServiceName != o.(*appsv1.StatefulSet).Spec.ServiceName {
+				err := c.Delete(ctx, currentSts, client.PropagationPolicy(metav1.DeletePropagationOrphan))
+				if err != nil && !apierrors.IsNotFound(err) {
+					return fmt.Errorf("failed to delete StatefulSet to change its service name: %v", err)
+				}
+				return errStatefulSetRecreating
+			}
 		case *corev1.Service:
 			currentSvc := current.(*corev1.Service)
 			svc := o.(*corev1.Service)
@@ -201,32 +277,45 @@ func createOrUpdateObjectWithClient(ctx context.Context, c client.Client, o clie
 	return err
 }
 
+// deleteOwnedObject deletes the object if it exists and the Vault is its controller,
+// an object with the same name created by someone else is left alone
+func (r *ReconcileVault) deleteOwnedObject(ctx context.Context, v *vaultv1alpha1.Vault, o client.Object) error {
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(o), o)
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !metav1.IsControlledBy(o, v) {
+		return nil
+	}
+
+	return client.IgnoreNotFound(r.client.Delete(ctx, o))
+}
+
 // Check if secret match the labels or annotations selectors
 // If any of the Labels selector OR Annotation Selector match it will return true
-func secretMatchLabelsOrAnnotations(s corev1.Secret, labelsSelectors []map[string]string, annotationsSelectors []map[string]string) bool {
+func secretMatchLabelsOrAnnotations(s corev1.Secret, labelsSelectors []labels.Selector, annotationsSelectors []labels.Selector) bool {
 	sm := s.ObjectMeta
-	log.V(1).Info(fmt.Sprintf("External Secrets Watcher: Checking labels and annotations for secret:  %s/%s", sm.GetNamespace(), sm.GetName()))
 
 	// Secret Labels
 	ol := sm.GetLabels()
-	// Iterate over labels selectors []map[string]string
+	// Iterate over labels selectors
 	for _, l := range labelsSelectors {
-		log.V(1).Info(fmt.Sprintf("External Secrets Watcher: Checking for labels selector: %v", l))
-		if labels.SelectorFromSet(l).Matches(labels.Set(ol)) {
+		if l.Matches(labels.Set(ol)) {
 			log.V(1).Info(fmt.Sprintf("External Secrets Watcher: Secret %s/%s matched label selector: %v", sm.GetNamespace(), sm.GetName(), l))
-			log.V(1).Info(fmt.Sprintf("External Secrets Watcher: adding Secret %s/%s to watch list", sm.GetNamespace(), sm.GetName()))
 			return true
 		}
 	}
 
 	// Secret Annotations
 	oa := sm.GetAnnotations()
-	// Iterate over annotations selectors []map[string]string
+	// Iterate over annotations selectors
 	for _, a := range annotationsSelectors {
-		log.V(1).Info(fmt.Sprintf("External Secrets Watcher: Checking for annotation selector: %v", a))
-		if labels.SelectorFromSet(a).Matches(labels.Set(oa)) {
+		if a.Matches(labels.Set(oa)) {
 			log.V(1).Info(fmt.Sprintf("External Secrets Watcher: Secret %s/%s matched annotation selector: %v", sm.GetNamespace(), sm.GetName(), a))
-			log.V(1).Info(fmt.Sprintf("External Secrets Watcher: adding Secret %s/%s to watch list", sm.GetNamespace(), sm.GetName()))
 			return true
 		}
 	}
@@ -234,6 +323,97 @@ func secretMatchLabelsOrAnnotations(s corev1.Secret, labelsSelectors []map[strin
 	return false
 }
 
+// vaultsForWatchedSecret returns a mapping function which enqueues only the Vault CRs
+// in the namespace of the changed Secret whose watched Secrets selectors match it
+func vaultsForWatchedSecret(c client.Client) handler.TypedMapFunc[*corev1.Secret, reconcile.Request] {
+	return func(ctx context.Context, secret *corev1.Secret) []reconcile.Request {
+		vaults := vaultv1alpha1.VaultList{}
+		if err := c.List(ctx, &vaults, client.InNamespace(secret.Namespace)); err != nil {
+			log.Error(err, "External Secrets Watcher: failed to list Vaults", "namespace", secret.Namespace)
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for i := range vaults.Items {
+			v := &vaults.Items[i]
+
+			labelsSelectors, annotationsSelectors, err := v.Spec.GetWatchedSecretsSelectors()
+			if err != nil {
+				log.Error(err, "External Secrets Watcher: failed to parse selectors", "vault", client.ObjectKeyFromObject(v))
+				continue
+			}
+
+			if secretMatchLabelsOrAnnotations(*secret, labelsSelectors, annotationsSelectors) {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(v)})
+			}
+		}
+
+		return requests
+	}
+}
+
+// vaultForConfigurerObject enqueues the Vault CR which owns a configurer ConfigMap or Secret,
+// based on the labels returned by LabelsForVaultConfigurer()
+func vaultForConfigurerObject[T client.Object](_ context.Context, o T) []reconcile.Request {
+	objectLabels := o.GetLabels()
+
+	v := vaultv1alpha1.Vault{}
+	v.Name = objectLabels["vault_cr"]
+	v.Namespace = o.GetNamespace()
+	if v.Name == "" || !labels.SelectorFromSet(v.LabelsForVaultConfigurer()).Matches(labels.Set(objectLabels)) {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(&v)}}
+}
+
+// watchedSecretsForVault returns the Secrets selected by the watched Secrets selectors of the Vault CR.
+// Label selectors are evaluated by the cache, annotation selectors are evaluated on the cached
+// objects without copying them, so only the matching Secrets are deep copied.
+func (r *ReconcileVault) watchedSecretsForVault(ctx context.Context, v *vaultv1alpha1.Vault) ([]corev1.Secret, error) {
+	labelsSelectors, annotationsSelectors, err := v.Spec.GetWatchedSecretsSelectors()
+	if err != nil {
+		return nil, err
+	}
+
+	matched := map[string]corev1.Secret{}
+
+	for _, selector := range labelsSelectors {
+		secrets := corev1.SecretList{}
+		if err := r.client.List(ctx, &secrets, client.InNamespace(v.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, fmt.Errorf("failed to list secrets in the CRD namespace: %v", err)
+		}
+
+		for _, secret := range secrets.Items {
+			matched[secret.Name] = secret
+		}
+	}
+
+	if len(annotationsSelectors) != 0 {
+		secrets := corev1.SecretList{}
+		if err := r.client.List(ctx, &secrets, client.InNamespace(v.Namespace), client.UnsafeDisableDeepCopy); err != nil {
+			return nil, fmt.Errorf("failed to list secrets in the CRD namespace: %v", err)
+		}
+
+		for i := range secrets.Items {
+			if _, ok := matched[secrets.Items[i].Name]; ok {
+				continue
+			}
+			if secretMatchLabelsOrAnnotations(secrets.Items[i], nil, annotationsSelectors) {
+				matched[secrets.Items[i].Name] = *secrets.Items[i].DeepCopy()
+			}
+		}
+	}
+
+	watchedSecrets := make([]corev1.Secret, 0, len(matched))
+	for _, secret := range matched {
+		watchedSecrets = append(watchedSecrets, secret)
+	}
+	sort.Slice(watchedSecrets, func(i, j int) bool { return watchedSecrets[i].Name < watchedSecrets[j].Name })
+
+	return watchedSecrets, nil
+}
+
 // Reconcile reads that state of the cluster for a Vault object and makes changes based on the state read
 // and what is in the Vault.Spec
 // Note:
@@ -251,17 +431,43 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			r.httpClients.forgetVault(request.NamespacedName)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, err
 	}
 
+	// Clean up what the owner references can't before the Vault is gone
+	if !v.DeletionTimestamp.IsZero() {
+		return r.finalizeVault(ctx, v)
+	}
+
+	// Keep Vault sealed while the emergency seal is set before anything else can fail
+	emergencySealResult, err := r.reconcileEmergencySeal(ctx, v)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	err = r.handleStorageConfiguration(ctx, v)
 	if err != nil {
 		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, err
 	}
 
+	// Warn about sensitive unseal fields in plain text, they end up in the Pod arguments
+	if fields := v.Spec.UnsealConfig.PlaintextSecrets(); len(fields) > 0 {
+		reqLogger.Info("Sensitive unseal fields are set in plain text, use Secret references instead", "fields", fields)
+		r.recorder.Eventf(v, corev1.EventTypeWarning, "PlaintextUnsealSecret",
+			"%s set in plain text, use the Secret reference alternatives instead", strings.Join(fields, ", "))
+	}
+
+	// The configurer Deployment has no identity of its own
+	storeRootToken := v.Spec.UnsealConfig.Options.StoreRootToken
+	if v.Spec.OperatorIdentity != nil && storeRootToken != nil && !*storeRootToken && !v.Spec.IsOperatorConfigurer() && len(v.Spec.ExternalConfig.Raw) != 0 {
+		r.recorder.Event(v, corev1.EventTypeWarning, "RootTokenRevoked",
+			"The configurer Deployment needs the root token, set configurerMode to operator")
+	}
+
 	// Create the service if it doesn't exist
 	service := serviceForVault(v)
 	// Set Vault instance as the owner and controller
@@ -289,6 +495,17 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 		}
 	}
 
+	// Create the headless service governing the StatefulSet, it gives every Vault pod a stable DNS name
+	headlessService := headlessServiceForVault(v)
+	// Set Vault instance as the owner and controller
+	if err := controllerutil.SetControllerReference(v, headlessService, r.scheme); err != nil {
+		return reconcile.Result{}, err
+	}
+	err = r.createOrUpdateObject(ctx, headlessService)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to create/update headless service: %v", err)
+	}
+
 	// Create the service if it doesn't exist
 	// NOTE: currently this is not used, but should be here once we implement support for Client Forwarding as well.
 	// Currently, request forwarding works only.
@@ -304,7 +521,39 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 		}
 	}
 
+	// Create the active and standby services, the pod labels they select are only maintained with service registration
+	if v.Spec.ServiceRegistrationEnabled {
+		for _, ser := range roleServicesForVault(v) {
+			// Set Vault instance as the owner and controller
+			if err := controllerutil.SetControllerReference(v, ser, r.scheme); err != nil {
+				return reconcile.Result{}, err
+			}
+			err = r.createOrUpdateObject(ctx, ser)
+			if err != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to create/update %s service: %v", ser.Name, err)
+			}
+		}
+	} else {
+		for _, name := range []string{v.ActiveServiceName(), v.StandbyServiceName()} {
+			ser := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: v.Namespace}}
+			if err := r.deleteOwnedObject(ctx, v, ser); err != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to delete %s service: %v", name, err)
+			}
+		}
+	}
+
+	if v.Spec.PerInstanceServicesDisabled {
+		for i := 0; i < int(v.Spec.Size); i++ {
+			ser := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: perInstanceVaultServiceName(v.Name, i), Namespace: v.Namespace}}
+			if err := r.deleteOwnedObject(ctx, v, ser); err != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to delete per instance service: %v", err)
+			}
+		}
+	}
+
 	tlsExpiration := time.Time{}
+	tlsRestartDate := ""
+	var caCertificate []byte
 	if !v.Spec.IsTLSDisabled() {
 		// Check if we have an existing TLS Secret for Vault
 		secretName := v.Name + "-tls"
@@ -333,7 +582,8 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 			}
 
 			tlsExpiration = certificate.NotAfter
-			tlsHostsChanged := certHostsAndIPsChanged(v, service, certificate)
+			certPodIPs := podIPsOfTLSSecret(sec)
+			tlsHostsChanged := certHostsAndIPsChanged(v, service, certificate, certPodIPs)
 
 			// Check if the ca.crt expiration date is closer than the server.crt expiration
 			if caData := sec.Data["ca.crt"]; len(caData) != 0 {
@@ -355,14 +605,30 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 				// Generate new TLS server certificate if the TLS hosts have changed
 				reqLogger.Info("TLS server hosts have changed")
 				tlsExpiration, err = populateTLSSecret(v, service, sec)
+			} else if !slices.Equal(certPodIPs, v.Status.PodIPs) {
+				// Generate new TLS server certificate with the current pod IPs, without restarting the pods:
+				// a restarted pod gets a new IP, so Vault picks the certificate up at its next restart
+				reqLogger.Info("Vault pod IPs have changed", "ips", v.Status.PodIPs)
+				tlsRestartDate = sec.Annotations[tlsExpirationAnnotation]
+				if tlsRestartDate == "" {
+					tlsRestartDate = tlsExpiration.UTC().Format(time.RFC3339)
+				}
+				tlsExpiration, err = populateTLSSecret(v, service, sec)
+			} else {
+				tlsRestartDate = sec.Annotations[tlsExpirationAnnotation]
 			}
 			if err != nil {
 				return reconcile.Result{}, fmt.Errorf("failed to fabricate secret for vault: %v", err)
 			}
 		}
 
+		if tlsRestartDate == "" {
+			tlsRestartDate = tlsExpiration.UTC().Format(time.RFC3339)
+		}
+
 		// Set Vault instance as the owner and controller
 		if v.Spec.ExistingTLSSecretName == "" {
+			sec.Annotations = withTLSRestartDate(sec.Annotations, tlsRestartDate)
 			if err := controllerutil.SetControllerReference(v, sec, r.scheme); err != nil {
 				return reconcile.Result{}, err
 			}
@@ -372,6 +638,11 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 			}
 		}
 
+		caCertificate = sec.Data["ca.crt"]
+		if ca, ok := sec.StringData["ca.crt"]; ok {
+			caCertificate = []byte(ca)
+		}
+
 		// Distribute the CA certificate to every namespace defined
 		if len(v.Spec.CANamespaces) > 0 {
 			err = r.distributeCACertificate(ctx, v, client.ObjectKey{Name: sec.Name, Namespace: sec.Namespace})
@@ -410,30 +681,48 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 		}
 	}
 
-	// Manage annotation for external secrets to watch and trigger restart of StatefulSet
-	externalSecretsToWatchLabelsSelector := v.Spec.GetWatchedSecretsLabels()
-	externalSecretsToWatchAnnotationsSelector := v.Spec.GetWatchedSecretsAnnotations()
-	externalSecretsToWatchItems := []corev1.Secret{}
-
-	if len(externalSecretsToWatchLabelsSelector) != 0 || len(externalSecretsToWatchAnnotationsSelector) != 0 {
-		externalSecretsInNamespace := corev1.SecretList{}
-		// Get all Secrets for the Vault CRD Namespace
-		externalSecretsInNamespaceFilter := client.ListOptions{
-			Namespace: v.Namespace,
-		}
+	// Start a seal migration if the unseal backend changed, before the config and the StatefulSet are rendered
+	err = r.startSealMigration(ctx, v)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 
-		if err = r.client.List(ctx, &externalSecretsInNamespace, &externalSecretsInNamespaceFilter); err != nil {
-			return reconcile.Result{}, fmt.Errorf("failed to list secrets in the CRD namespace: %v", err)
-		}
+	// Manage annotation for external secrets to watch and trigger restart of StatefulSet
+	externalSecretsToWatchItems, err := r.watchedSecretsForVault(ctx, v)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 
-		for _, secret := range externalSecretsInNamespace.Items {
-			if secretMatchLabelsOrAnnotations(secret, externalSecretsToWatchLabelsSelector, externalSecretsToWatchAnnotationsSelector) {
-				externalSecretsToWatchItems = append(externalSecretsToWatchItems, secret)
+	// Prepare the Vault referenced for transit auto-unseal, this Vault can't start before it
+	var transitSeal map[string]interface{}
+	if v.Spec.UnsealConfig.VaultRef != nil {
+		if controllerutil.AddFinalizer(v, transitUnsealFinalizer) {
+			if err := r.client.Update(ctx, v); err != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to add transit unseal finalizer: %v", err)
 			}
 		}
+		transitSeal, err = r.transitSealForVault(ctx, v)
+		if err != nil {
+			reqLogger.Info("The Vault referenced for transit auto-unseal is not ready, waiting 10 seconds...", "reason", err.Error())
+			r.recorder.Event(v, corev1.EventTypeWarning, "TransitUnsealNotReady", err.Error())
+			return reconcile.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+		}
+	} else if controllerutil.ContainsFinalizer(v, transitUnsealFinalizer) && !v.Status.SealMigration.InProgress() {
+		// The Vault doesn't auto-unseal with the referenced Vault anymore
+		if err := r.releaseTransitUnseal(ctx, v); err != nil {
+			r.recorder.Event(v, corev1.EventTypeWarning, "TransitUnsealReleaseFailed", err.Error())
+			return reconcile.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+		}
+		if err := r.deleteOwnedObject(ctx, v, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: v.Namespace, Name: v.TransitUnsealSecretName()}}); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to delete transit unseal secret: %v", err)
+		}
+		controllerutil.RemoveFinalizer(v, transitUnsealFinalizer)
+		if err := r.client.Update(ctx, v); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to remove transit unseal finalizer: %v", err)
+		}
 	}
 
-	rawConfigSecret, rawConfigSum, err := secretForRawVaultConfig(v)
+	rawConfigSecret, rawConfigSum, err := secretForRawVaultConfig(v, transitSeal)
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to fabricate Secret: %v", err)
 	}
@@ -448,9 +737,18 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 		return reconcile.Result{}, fmt.Errorf("failed to create/update Secret: %v", err)
 	}
 
+	// Create the ServiceAccount and the RBAC resources of the Vault Pods if managed by the operator
+	err = r.rbacForVault(ctx, v)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// Create the StatefulSet if it doesn't exist
 	restartAnnotations := map[string]string{}
-	restartAnnotations["vault.banzaicloud.io/tls-expiration-date"] = tlsExpiration.UTC().Format(time.RFC3339)
+	if tlsRestartDate == "" {
+		tlsRestartDate = tlsExpiration.UTC().Format(time.RFC3339)
+	}
+	restartAnnotations[tlsExpirationAnnotation] = tlsRestartDate
 	restartAnnotations["vault.banzaicloud.io/vault-config"] = rawConfigSum
 	statefulSet, err := statefulSetForVault(v, externalSecretsToWatchItems, restartAnnotations, service)
 	if err != nil {
@@ -463,10 +761,71 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 	}
 
 	err = r.createOrUpdateObject(ctx, statefulSet)
+	if errors.Is(err, errStatefulSetRecreating) {
+		log.Info("waiting for the StatefulSet to be deleted before creating it again", "vault", v.Name)
+		return reconcile.Result{RequeueAfter: 5 * time.Second}, nil
+	}
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to create/update StatefulSet: %v", err)
 	}
 
+	// The unsealer would unseal the pods again, they are restarted without it as soon as the StatefulSet
+	// controller has seen the updated pod template, which is waited for by requeueing right away
+	if v.IsEmergencySealed() && !v.Spec.IsAutoUnseal() {
+		restarted, err := r.restartPodsForEmergencySeal(ctx, v)
+		if err != nil {
+			r.recorder.Event(v, corev1.EventTypeWarning, "EmergencySealFailed", err.Error())
+			return reconcile.Result{}, fmt.Errorf("failed to restart pods for the emergency seal: %v", err)
+		}
+		if !restarted {
+			emergencySealResult = reconcile.Result{Requeue: true}
+		}
+	}
+
+	// Create the PodDisruptionBudget, so node drains don't evict more Vault pods than the cluster can tolerate,
+	// the single configurer pod is left out, a budget would block the drains of its node
+	if v.Spec.IsPodDisruptionBudgetEnabled() {
+		pdb := podDisruptionBudgetForVault(v)
+		// Set Vault instance as the owner and controller
+		if err := controllerutil.SetControllerReference(v, pdb, r.scheme); err != nil {
+			return reconcile.Result{}, err
+		}
+		err = r.createOrUpdateObject(ctx, pdb)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to create/update PodDisruptionBudget: %v", err)
+		}
+		if v.Spec.IsPodDisruptionBudgetQuorumLost() {
+			r.recorder.Event(v, corev1.EventTypeWarning, "PodDisruptionBudgetQuorumLost",
+				"A two member Raft cluster loses its quorum when one member is evicted, the PodDisruptionBudget only keeps one member running")
+		}
+	} else {
+		pdb := &policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: v.Name, Namespace: v.Namespace}}
+		if err := r.deleteOwnedObject(ctx, v, pdb); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to delete PodDisruptionBudget: %v", err)
+		}
+	}
+
+	// Create the NetworkPolicies if enabled
+	if v.Spec.NetworkPolicy != nil && v.Spec.NetworkPolicy.Enabled {
+		for _, np := range networkPoliciesForVault(v) {
+			// Set Vault instance as the owner and controller
+			if err := controllerutil.SetControllerReference(v, np, r.scheme); err != nil {
+				return reconcile.Result{}, err
+			}
+			err = r.createOrUpdateObject(ctx, np)
+			if err != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to create/update NetworkPolicy: %v", err)
+			}
+		}
+	} else {
+		for _, name := range []string{v.Name, v.Name + "-configurer"} {
+			np := &netv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: v.Namespace}}
+			if err := r.deleteOwnedObject(ctx, v, np); err != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to delete NetworkPolicy: %v", err)
+			}
+		}
+	}
+
 	if v.Spec.ServiceMonitorEnabled {
 		// Create the ServiceMonitor if it doesn't exist
 		serviceMonitor := serviceMonitorForVault(v)
@@ -480,8 +839,13 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 		}
 	}
 
-	// Create configurer if there is any external config
-	if len(v.Spec.ExternalConfig.Raw) != 0 {
+	// Create configurer if there is any external config, unless the operator applies it itself
+	if v.Spec.IsOperatorConfigurer() {
+		err := r.removeConfigurer(ctx, v)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	} else if len(v.Spec.ExternalConfig.Raw) != 0 {
 		err := r.deployConfigurer(ctx, v, restartAnnotations)
 		if err != nil {
 			return reconcile.Result{}, err
@@ -501,6 +865,12 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 		}
 	}
 
+	// Create Gateway API route if specified
+	err = r.gatewayRoutesForVault(ctx, v, caCertificate)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to create/update gateway route: %v", err)
+	}
+
 	// Update the Vault status with the pod names
 	podList := podList()
 	labelSelector := labels.SelectorFromSet(v.LabelsForVault())
@@ -513,19 +883,16 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 		return reconcile.Result{}, fmt.Errorf("failed to list pods: %v", err)
 	}
 	podNames := getPodNames(podList.Items)
+	podIPs := getPodIPs(podList.Items)
 
 	var leader string
 	var statusError string
 	for i := 0; i < int(v.Spec.Size); i++ {
-		tmpClient, err := vault.NewInsecureRawClient()
-		if err != nil {
-			return reconcile.Result{}, err
-		}
-
 		podName := fmt.Sprintf("%s-%d", v.Name, i)
-		err = tmpClient.SetAddress(fmt.Sprintf("%s://%s.%s:8200", strings.ToLower(string(getVaultURIScheme(v))), podName, v.Namespace))
+		tmpClient, err := r.httpClients.vaultClientForPod(ctx, r.client, v, podName)
 		if err != nil {
-			return reconcile.Result{}, err
+			statusError = err.Error()
+			break
 		}
 
 		health, err := tmpClient.Sys().Health()
@@ -557,15 +924,197 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 		conditionStatus = corev1.ConditionTrue
 	}
 
-	if !reflect.DeepEqual(podNames, v.Status.Nodes) || !reflect.DeepEqual(leader, v.Status.Leader) || len(v.Status.Conditions) == 0 ||
-		v.Status.Conditions[0].Status != conditionStatus || v.Status.Conditions[0].Error != statusError {
+	statusChanged := v.Status.SetCondition(corev1.ComponentCondition{
+		Type:   corev1.ComponentHealthy,
+		Status: conditionStatus,
+		Error:  statusError,
+	})
+
+	// The operator doesn't unseal Vault while the emergency seal is set either
+	result := emergencySealResult
+
+	// Act on the pods which stay sealed, uninitialised or unreachable, they are expected to during a seal migration
+	if v.Spec.Remediation != nil && !v.IsEmergencySealed() && !v.Status.SealMigration.InProgress() {
+		remediation := v.Status.Remediation.DeepCopy()
+		next := r.remediatePods(ctx, v)
+		statusChanged = !reflect.DeepEqual(remediation, v.Status.Remediation) || statusChanged
+		if next > 0 && (result.RequeueAfter == 0 || next < result.RequeueAfter) {
+			result.RequeueAfter = next
+		}
+	} else if v.Status.Remediation != nil {
+		v.Status.Remediation = nil
+		statusChanged = true
+	}
+
+	// Initialise and unseal Vault with the key shares of the custodians
+	if v.Spec.UnsealConfig.OperatorUnseals() && !v.IsEmergencySealed() {
+		condition := r.unsealWithCustodians(ctx, v)
+		statusChanged = v.Status.SetCondition(condition) || statusChanged
+		if condition.Status != corev1.ConditionTrue {
+			// The submission Secret is not watched
+			result = reconcile.Result{RequeueAfter: 10 * time.Second}
+		}
+	}
+
+	// Initialise Vault with encrypted keys, the unsealer would store its own plain keys
+	if v.Spec.UnsealConfig.IsEncrypted() {
+		if err := r.prepareEncryptedKeys(ctx, v); err != nil {
+			r.recorder.Event(v, corev1.EventTypeWarning, "EncryptedKeysFailed", err.Error())
+			result = reconcile.Result{RequeueAfter: 10 * time.Second}
+		}
+	}
+
+	// Vault reviews the ServiceAccount tokens of the KubernetesAuth and the OperatorIdentity with its own ServiceAccount
+	if v.Spec.KubernetesAuth != nil || v.Spec.OperatorIdentity != nil {
+		condition, err := r.bindAuthDelegator(ctx, v)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if v.Status.SetCondition(condition) {
+			statusChanged = true
+			if condition.Status != corev1.ConditionTrue {
+				r.recorder.Event(v, corev1.EventTypeWarning, "AuthDelegatorNotBound", condition.Error)
+			}
+		}
+	} else {
+		if err := r.releaseAuthDelegator(ctx, v); err != nil {
+			return reconcile.Result{}, err
+		}
+		statusChanged = v.Status.RemoveCondition(vaultv1alpha1.AuthDelegatorBoundCondition) || statusChanged
+	}
+
+	// Bootstrap the Vault identity of the operator before anything else needs the root token
+	if v.Spec.OperatorIdentity != nil && conditionStatus == corev1.ConditionTrue {
+		condition, hash := r.bootstrapOperatorIdentity(ctx, v, leader)
+		if condition.Status == corev1.ConditionTrue {
+			if v.Status.OperatorIdentityHash != hash {
+				v.Status.OperatorIdentityHash = hash
+				statusChanged = true
+			}
+		} else {
+			log.Error(errors.New(condition.Error), "failed to bootstrap operator identity", "vault", v.Name)
+			if result.RequeueAfter == 0 || 30*time.Second < result.RequeueAfter {
+				result.RequeueAfter = 30 * time.Second
+			}
+		}
+		statusChanged = v.Status.SetCondition(condition) || statusChanged
+	} else if v.Spec.OperatorIdentity == nil && v.Status.OperatorIdentityHash != "" {
+		v.Status.OperatorIdentityHash = ""
+		statusChanged = true
+	}
+
+	// Apply the external config through the Vault API if the operator is the configurer
+	if v.Spec.IsOperatorConfigurer() && len(v.Spec.ExternalConfig.Raw) != 0 && conditionStatus == corev1.ConditionTrue {
+		configHash := externalConfigHash(v)
+		applied := v.Status.GetCondition(vaultv1alpha1.ConfigurationAppliedCondition)
+		if applied == nil || applied.Status != corev1.ConditionTrue || v.Status.ConfigurationHash != configHash {
+			condition := r.configureVault(ctx, v, leader)
+			if condition.Status == corev1.ConditionTrue {
+				v.Status.ConfigurationHash = configHash
+				log.Info("Applied external config", "vault", v.Name, "hash", configHash)
+			} else {
+				log.Error(errors.New(condition.Error), "failed to apply external config", "vault", v.Name)
+				result = reconcile.Result{RequeueAfter: 30 * time.Second}
+			}
+			statusChanged = v.Status.SetCondition(condition) || statusChanged
+		}
+	} else if !v.Spec.IsOperatorConfigurer() {
+		// The configurer Deployment doesn't report what it applied, a condition of the operator would be stale
+		if v.Status.RemoveCondition(vaultv1alpha1.ConfigurationAppliedCondition) || v.Status.ConfigurationHash != "" {
+			v.Status.ConfigurationHash = ""
+			statusChanged = true
+		}
+	}
+
+	// Enable and configure the Kubernetes auth method declared in the spec
+	if v.Spec.KubernetesAuth != nil && conditionStatus == corev1.ConditionTrue {
+		condition, hash := r.configureKubernetesAuth(ctx, v, leader)
+		if condition.Status == corev1.ConditionTrue {
+			if v.Status.KubernetesAuthHash != hash {
+				v.Status.KubernetesAuthHash = hash
+				statusChanged = true
+			}
+		} else {
+			log.Error(errors.New(condition.Error), "failed to configure kubernetes auth", "vault", v.Name)
+			if result.RequeueAfter == 0 || 30*time.Second < result.RequeueAfter {
+				result.RequeueAfter = 30 * time.Second
+			}
+		}
+		statusChanged = v.Status.SetCondition(condition) || statusChanged
+	}
+
+	// Compare Vault with the external config periodically if drift detection is enabled
+	if v.Spec.DriftDetection != nil && len(v.Spec.ExternalConfig.Raw) != 0 && conditionStatus == corev1.ConditionTrue {
+		interval := v.Spec.DriftDetection.GetInterval()
+		if v.Status.LastDriftCheckTime == nil || time.Since(v.Status.LastDriftCheckTime.Time) >= interval {
+			condition, drift := r.checkDrift(ctx, v, leader)
+			if len(drift) != 0 {
+				log.Info("drift detected from external config", "vault", v.Name, "drift", drift)
+			}
+			now := metav1.Now()
+			v.Status.LastDriftCheckTime = &now
+			v.Status.Drift = drift
+			v.Status.SetCondition(condition)
+			statusChanged = true
+		}
+		if result.RequeueAfter == 0 || interval < result.RequeueAfter {
+			result.RequeueAfter = interval
+		}
+	}
+
+	// Report the recovery keys of an auto-unsealed Vault, and keep them in their own Secret if configured
+	if v.Spec.IsAutoUnseal() && conditionStatus == corev1.ConditionTrue && !v.Status.SealMigration.InProgress() {
+		recoveryKeys, err := r.recoveryKeysForVault(ctx, v, leader)
+		if err != nil {
+			log.Error(err, "failed to check recovery keys", "vault", v.Name)
+		}
+		if recoveryKeys != nil && !reflect.DeepEqual(recoveryKeys, v.Status.RecoveryKeys) {
+			v.Status.RecoveryKeys = recoveryKeys
+			statusChanged = true
+		}
+	}
+
+	// Rekey Vault and regenerate the root token when they are due
+	if v.Spec.UnsealConfig.Options.Rotation != nil && conditionStatus == corev1.ConditionTrue && !v.Status.SealMigration.InProgress() {
+		keyRotation := v.Status.KeyRotation.DeepCopy()
+		condition, next := r.rotateKeys(ctx, v, leader)
+		if v.Status.SetCondition(condition) || !reflect.DeepEqual(keyRotation, v.Status.KeyRotation) {
+			statusChanged = true
+		}
+		if next > 0 && (result.RequeueAfter == 0 || next < result.RequeueAfter) {
+			result.RequeueAfter = next
+		}
+	}
+
+	if v.Status.SealMigration.InProgress() {
+		// Move the Vault pods to the new seal one at a time
+		migration, seal := v.Status.SealMigration.DeepCopy(), v.Status.Seal.DeepCopy()
+		err := r.migrateSeal(ctx, v, leader)
+		if err != nil {
+			log.Info("seal migration is waiting", "vault", v.Name, "reason", err.Error())
+			v.Status.SealMigration.Message = err.Error()
+		}
+		if !reflect.DeepEqual(migration, v.Status.SealMigration) || !reflect.DeepEqual(seal, v.Status.Seal) {
+			statusChanged = true
+		}
+		if result.RequeueAfter == 0 || 5*time.Second < result.RequeueAfter {
+			result.RequeueAfter = 5 * time.Second
+		}
+	} else if !v.Status.SealMigration.InProgress() && conditionStatus == corev1.ConditionTrue &&
+		(v.Status.Seal == nil || !v.Spec.UnsealConfig.Options.SealMigration) {
+		// Record the seal of the initialised Vault, seal migrations start from it
+		seal := sealForVault(v)
+		if v.Status.Seal == nil || !reflect.DeepEqual(*v.Status.Seal, seal) {
+			v.Status.Seal = &seal
+			statusChanged = true
+		}
+	}
+
+	if !reflect.DeepEqual(podNames, v.Status.Nodes) || !slices.Equal(podIPs, v.Status.PodIPs) ||
+		!reflect.DeepEqual(leader, v.Status.Leader) || statusChanged {
 		v.Status.Nodes = podNames
+		v.Status.PodIPs = podIPs
 		v.Status.Leader = leader
-		v.Status.Conditions = []corev1.ComponentCondition{{
-			Type:   corev1.ComponentHealthy,
-			Status: conditionStatus,
-			Error:  statusError,
-		}}
 		log.V(1).Info("Updating vault status", "status", v.Status, "resourceVersion", v.ResourceVersion)
 		err := r.client.Update(ctx, v)
 		if err != nil {
@@ -573,26 +1122,46 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 		}
 	}
 
-	return reconcile.Result{}, nil
+	return result, nil
 }
 
-func newHTTPClient() *http.Client {
-	return &http.Client{
-		Timeout: 2 * time.Second,
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		},
+// finalizeVault releases what the Vault holds outside of its owned objects, and lets it go afterwards
+func (r *ReconcileVault) finalizeVault(ctx context.Context, v *vaultv1alpha1.Vault) (reconcile.Result, error) {
+	if controllerutil.ContainsFinalizer(v, transitUnsealFinalizer) {
+		if err := r.releaseTransitUnseal(ctx, v); err != nil {
+			r.recorder.Event(v, corev1.EventTypeWarning, "TransitUnsealReleaseFailed", err.Error())
+			return reconcile.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+		}
+		controllerutil.RemoveFinalizer(v, transitUnsealFinalizer)
+		if err := r.client.Update(ctx, v); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to remove transit unseal finalizer: %v", err)
+		}
 	}
+
+	if err := r.releaseAuthDelegator(ctx, v); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	r.httpClients.forgetVault(client.ObjectKeyFromObject(v))
+	return reconcile.Result{}, nil
 }
 
-func secretForRawVaultConfig(v *vaultv1alpha1.Vault) (*corev1.Secret, string, error) {
+func secretForRawVaultConfig(v *vaultv1alpha1.Vault, transitSeal map[string]interface{}) (*corev1.Secret, string, error) {
 	configJSON, err := v.ConfigJSON()
 	if err != nil {
 		return nil, "", err
 	}
 
+	configJSON, err = withTransitSeal(v, configJSON, transitSeal)
+	if err != nil {
+		return nil, "", err
+	}
+
+	configJSON, err = withRaftRetryJoin(v, configJSON)
+	if err != nil {
+		return nil, "", err
+	}
+
 	secret := corev1.Secret{}
 	secret.Name = v.Name + "-raw-config"
 	secret.Namespace = v.Namespace
@@ -604,6 +1173,41 @@ func secretForRawVaultConfig(v *vaultv1alpha1.Vault) (*corev1.Secret, string, er
 	return &secret, fmt.Sprintf("%x", sha256.Sum256(configJSON)), nil
 }
 
+// withRaftRetryJoin lets the Raft storage join the cluster through the pod DNS names if RaftRetryJoin is set,
+// unless the config already has a retry_join stanza or joins a leader in another cluster
+func withRaftRetryJoin(v *vaultv1alpha1.Vault, configJSON []byte) ([]byte, error) {
+	if !v.Spec.RaftRetryJoin || !v.Spec.IsRaftStorage() || v.Spec.IsRaftBootstrapFollower() {
+		return configJSON, nil
+	}
+
+	config := map[string]interface{}{}
+	if err := json.Unmarshal(configJSON, &config); err != nil {
+		return nil, err
+	}
+
+	storage := cast.ToStringMap(config["storage"])
+	raft := cast.ToStringMap(storage["raft"])
+	if _, ok := raft["retry_join"]; ok {
+		return configJSON, nil
+	}
+
+	var retryJoin []interface{}
+	for i := 0; i < int(v.Spec.Size); i++ {
+		join := map[string]interface{}{
+			"leader_api_addr": podAddressForVault(v, perInstanceVaultServiceName(v.Name, i)),
+		}
+		if !v.Spec.IsTLSDisabled() {
+			join["leader_ca_cert_file"] = "/vault/tls/ca.crt"
+		}
+		retryJoin = append(retryJoin, join)
+	}
+	raft["retry_join"] = retryJoin
+	storage["raft"] = raft
+	config["storage"] = storage
+
+	return json.Marshal(config)
+}
+
 func serviceForVault(v *vaultv1alpha1.Vault) *corev1.Service {
 	ls := v.LabelsForVault()
 	// label to differentiate per-instance service and global service via label selection
@@ -611,18 +1215,13 @@ func serviceForVault(v *vaultv1alpha1.Vault) *corev1.Service {
 
 	selectorLs := v.LabelsForVault()
 	// add the service_registration label
-	if v.Spec.ServiceRegistrationEnabled {
+	if v.Spec.IsMainServiceActiveOnly() {
 		selectorLs["vault-active"] = "true"
 	}
 
 	servicePorts, _ := getServicePorts(v)
 
-	annotations := withVaultAnnotations(v, getCommonAnnotations(v, map[string]string{}))
-
-	// On GKE we need to specify the backend protocol on the service if TLS is enabled
-	if ingress := v.GetIngress(); ingress != nil && !v.Spec.IsTLSDisabled() {
-		annotations["cloud.google.com/app-protocols"] = fmt.Sprintf("{\"%s\":\"HTTPS\"}", v.Spec.GetAPIPortName())
-	}
+	annotations := withAppProtocolsAnnotation(v, withVaultAnnotations(v, getCommonAnnotations(v, map[string]string{})))
 
 	servicePorts = append(servicePorts, corev1.ServicePort{Name: "metrics", Port: 9091})
 	servicePorts = append(servicePorts, corev1.ServicePort{Name: "statsd", Port: 9102})
@@ -634,9 +1233,11 @@ func serviceForVault(v *vaultv1alpha1.Vault) *corev1.Service {
 			Labels:      withVaultLabels(v, ls),
 		},
 		Spec: corev1.ServiceSpec{
-			Type:     serviceType(v),
-			Selector: selectorLs,
-			Ports:    servicePorts,
+			Type:           serviceType(v),
+			Selector:       selectorLs,
+			Ports:          servicePorts,
+			IPFamilyPolicy: v.Spec.IPFamilyPolicy,
+			IPFamilies:     v.Spec.IPFamilies,
 			// Optional setting for requesting specific load balancer ip addresses.
 			LoadBalancerIP: v.Spec.LoadBalancerIP,
 			// In case of multi-cluster deployments we need to publish the port
@@ -648,6 +1249,128 @@ func serviceForVault(v *vaultv1alpha1.Vault) *corev1.Service {
 	return service
 }
 
+// networkPoliciesForVault returns the NetworkPolicies of the Vault and the configurer pods, only ingress traffic
+// is restricted, the sidecars in the Vault pods talk to Vault on localhost
+func networkPoliciesForVault(v *vaultv1alpha1.Vault) []*netv1.NetworkPolicy {
+	config := v.Spec.NetworkPolicy
+
+	monitoringNamespace := config.MonitoringNamespace
+	if monitoringNamespace == "" {
+		monitoringNamespace = v.Namespace
+	}
+	monitoringPeers := []netv1.NetworkPolicyPeer{{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{corev1.LabelMetadataName: monitoringNamespace},
+		},
+	}}
+
+	vaultPeers := []netv1.NetworkPolicyPeer{{
+		PodSelector: &metav1.LabelSelector{MatchLabels: v.LabelsForVault()},
+	}}
+
+	operatorNamespaces := &metav1.LabelSelector{}
+	if OperatorNamespace != "" {
+		operatorNamespaces.MatchLabels = map[string]string{corev1.LabelMetadataName: OperatorNamespace}
+	}
+
+	apiPeers := append([]netv1.NetworkPolicyPeer{
+		{PodSelector: &metav1.LabelSelector{MatchLabels: v.LabelsForVaultConfigurer()}},
+		// The operator checks the health of the Vault pods and may apply the configuration
+		{
+			NamespaceSelector: operatorNamespaces,
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app.kubernetes.io/name": "vault-operator"},
+			},
+		},
+	}, config.APIPeers...)
+
+	policy := func(name string, podSelector map[string]string, rules []netv1.NetworkPolicyIngressRule) *netv1.NetworkPolicy {
+		return &netv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   v.Namespace,
+				Annotations: withVaultAnnotations(v, getCommonAnnotations(v, map[string]string{})),
+				Labels:      withVaultLabels(v, v.LabelsForVault()),
+			},
+			Spec: netv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: podSelector},
+				PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeIngress},
+				Ingress:     rules,
+			},
+		}
+	}
+
+	return []*netv1.NetworkPolicy{
+		policy(v.Name, v.LabelsForVault(), []netv1.NetworkPolicyIngressRule{
+			{
+				From:  vaultPeers,
+				Ports: networkPolicyPorts(v.Spec.GetAPIPort(), v.Spec.GetClusterPort()),
+			},
+			{
+				From:  apiPeers,
+				Ports: networkPolicyPorts(v.Spec.GetAPIPort()),
+			},
+			// The ServiceMonitor scrapes /v1/sys/metrics on the API port
+			{
+				From:  monitoringPeers,
+				Ports: networkPolicyPorts(v.Spec.GetAPIPort(), 9091, 9102),
+			},
+		}),
+		policy(v.Name+"-configurer", v.LabelsForVaultConfigurer(), []netv1.NetworkPolicyIngressRule{
+			{
+				From:  monitoringPeers,
+				Ports: networkPolicyPorts(9091),
+			},
+		}),
+	}
+}
+
+func networkPolicyPorts(ports ...int32) []netv1.NetworkPolicyPort {
+	var policyPorts []netv1.NetworkPolicyPort
+	for _, port := range ports {
+		policyPorts = append(policyPorts, netv1.NetworkPolicyPort{
+			Protocol: ptr.To(corev1.ProtocolTCP),
+			Port:     ptr.To(intstr.FromInt32(port)),
+		})
+	}
+	return policyPorts
+}
+
+func podDisruptionBudgetForVault(v *vaultv1alpha1.Vault) *policyv1.PodDisruptionBudget {
+	ls := v.LabelsForVault()
+
+	spec := policyv1.PodDisruptionBudgetSpec{
+		Selector: &metav1.LabelSelector{MatchLabels: ls},
+	}
+
+	config := v.Spec.PodDisruptionBudget
+	switch {
+	case config != nil && config.MinAvailable != nil:
+		spec.MinAvailable = config.MinAvailable
+	case config != nil && config.MaxUnavailable != nil:
+		spec.MaxUnavailable = config.MaxUnavailable
+	case v.Spec.IsPodDisruptionBudgetQuorumLost():
+		// Two members have no majority to keep, allow draining one of them at least
+		spec.MinAvailable = ptr.To(intstr.FromInt32(1))
+	case v.Spec.IsRaftStorage() || v.Spec.IsRaftHAStorage():
+		// Keep a majority of the Raft members running
+		spec.MaxUnavailable = ptr.To(intstr.FromInt32((v.Spec.Size - 1) / 2))
+	default:
+		// Keep at least one instance running, the standbys don't form a quorum
+		spec.MaxUnavailable = ptr.To(intstr.FromInt32(v.Spec.Size - 1))
+	}
+
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        v.Name,
+			Namespace:   v.Namespace,
+			Annotations: withVaultAnnotations(v, getCommonAnnotations(v, map[string]string{})),
+			Labels:      withVaultLabels(v, ls),
+		},
+		Spec: spec,
+	}
+}
+
 func serviceMonitorForVault(v *vaultv1alpha1.Vault) *monitorv1.ServiceMonitor {
 	ls := v.LabelsForVault()
 	serviceMonitor := &monitorv1.ServiceMonitor{
@@ -710,20 +1433,20 @@ func getServicePorts(v *vaultv1alpha1.Vault) ([]corev1.ServicePort, []corev1.Con
 		return []corev1.ServicePort{
 				{
 					Name: v.Spec.GetAPIPortName(),
-					Port: 8200,
+					Port: v.Spec.GetAPIPort(),
 				},
 				{
 					Name: "cluster-port",
-					Port: 8201,
+					Port: v.Spec.GetClusterPort(),
 				},
 			}, []corev1.ContainerPort{
 				{
 					Name:          v.Spec.GetAPIPortName(),
-					ContainerPort: 8200,
+					ContainerPort: v.Spec.GetAPIPort(),
 				},
 				{
 					Name:          "cluster-port",
-					ContainerPort: 8201,
+					ContainerPort: v.Spec.GetClusterPort(),
 				},
 			}
 	}
@@ -748,12 +1471,58 @@ func getServicePorts(v *vaultv1alpha1.Vault) ([]corev1.ServicePort, []corev1.Con
 	return servicePorts, containerPorts
 }
 
+func apiPort(v *vaultv1alpha1.Vault) string {
+	return strconv.Itoa(int(v.Spec.GetAPIPort()))
+}
+
+func clusterPort(v *vaultv1alpha1.Vault) string {
+	return strconv.Itoa(int(v.Spec.GetClusterPort()))
+}
+
 func perInstanceVaultServiceName(svc string, i int) string {
 	return fmt.Sprintf("%s-%d", svc, i)
 }
 
+func headlessServiceName(v *vaultv1alpha1.Vault) string {
+	return v.Name + "-headless"
+}
+
+// podFQDN returns the DNS name of a Vault pod in the headless Service
+func podFQDN(v *vaultv1alpha1.Vault, podName string) string {
+	return podName + "." + serviceFQDN(headlessServiceName(v), v.Namespace)
+}
+
+func headlessServiceForVault(v *vaultv1alpha1.Vault) *corev1.Service {
+	ls := v.LabelsForVault()
+	servicePorts, _ := getServicePorts(v)
+	servicePorts = append(servicePorts, corev1.ServicePort{Name: "metrics", Port: 9091})
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        headlessServiceName(v),
+			Namespace:   v.Namespace,
+			Annotations: withVaultAnnotations(v, getCommonAnnotations(v, map[string]string{})),
+			Labels:      withVaultLabels(v, ls),
+		},
+		Spec: corev1.ServiceSpec{
+			Type:           corev1.ServiceTypeClusterIP,
+			ClusterIP:      corev1.ClusterIPNone,
+			Selector:       ls,
+			Ports:          servicePorts,
+			IPFamilyPolicy: v.Spec.IPFamilyPolicy,
+			IPFamilies:     v.Spec.IPFamilies,
+			// The pods have to be resolvable before they are unsealed, so they can join the Raft cluster
+			PublishNotReadyAddresses: true,
+		},
+	}
+}
+
 func perInstanceServicesForVault(v *vaultv1alpha1.Vault) []*corev1.Service {
 	var services []*corev1.Service
+	if v.Spec.PerInstanceServicesDisabled {
+		return services
+	}
+
 	servicePorts, _ := getServicePorts(v)
 	servicePorts = append(servicePorts, corev1.ServicePort{Name: "metrics", Port: 9091})
 
@@ -775,6 +1544,8 @@ func perInstanceServicesForVault(v *vaultv1alpha1.Vault) []*corev1.Service {
 				Selector:                 ls,
 				Ports:                    servicePorts,
 				PublishNotReadyAddresses: true,
+				IPFamilyPolicy:           v.Spec.IPFamilyPolicy,
+				IPFamilies:               v.Spec.IPFamilies,
 			},
 		}
 
@@ -784,6 +1555,55 @@ func perInstanceServicesForVault(v *vaultv1alpha1.Vault) []*corev1.Service {
 	return services
 }
 
+// withAppProtocolsAnnotation sets the backend protocol of the Services behind the Ingress on GKE if TLS is enabled
+func withAppProtocolsAnnotation(v *vaultv1alpha1.Vault, annotations map[string]string) map[string]string {
+	if ingress := v.GetIngress(); ingress != nil && !v.Spec.IsTLSDisabled() {
+		annotations["cloud.google.com/app-protocols"] = fmt.Sprintf("{\"%s\":\"HTTPS\"}", v.Spec.GetAPIPortName())
+	}
+	return annotations
+}
+
+// roleServicesForVault returns the Services selecting the active and the standby Vault instances
+// through the labels maintained by the Vault service_registration
+func roleServicesForVault(v *vaultv1alpha1.Vault) []*corev1.Service {
+	servicePorts, _ := getServicePorts(v)
+
+	activeSelector := v.LabelsForVault()
+	activeSelector["vault-active"] = "true"
+
+	standbySelector := v.LabelsForVault()
+	if v.Spec.PerformanceStandbyServiceEnabled {
+		standbySelector["vault-perf-standby"] = "true"
+	} else {
+		standbySelector["vault-active"] = "false"
+	}
+
+	var services []*corev1.Service
+	for name, selector := range map[string]map[string]string{
+		v.ActiveServiceName():  activeSelector,
+		v.StandbyServiceName(): standbySelector,
+	} {
+		services = append(services, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   v.Namespace,
+				Annotations: withAppProtocolsAnnotation(v, withVaultAnnotations(v, getCommonAnnotations(v, map[string]string{}))),
+				Labels:      withVaultLabels(v, v.LabelsForVault()),
+			},
+			Spec: corev1.ServiceSpec{
+				Type:           corev1.ServiceTypeClusterIP,
+				Selector:       selector,
+				Ports:          servicePorts,
+				IPFamilyPolicy: v.Spec.IPFamilyPolicy,
+				IPFamilies:     v.Spec.IPFamilies,
+			},
+		})
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].Name < services[j].Name })
+
+	return services
+}
+
 func serviceForVaultConfigurer(v *vaultv1alpha1.Vault) *corev1.Service {
 	var servicePorts []corev1.ServicePort
 
@@ -800,9 +1620,11 @@ func serviceForVaultConfigurer(v *vaultv1alpha1.Vault) *corev1.Service {
 			Labels:      withVaultConfigurerLabels(v, ls),
 		},
 		Spec: corev1.ServiceSpec{
-			Type:     corev1.ServiceTypeClusterIP,
-			Selector: ls,
-			Ports:    servicePorts,
+			Type:           corev1.ServiceTypeClusterIP,
+			Selector:       ls,
+			Ports:          servicePorts,
+			IPFamilyPolicy: v.Spec.IPFamilyPolicy,
+			IPFamilies:     v.Spec.IPFamilies,
 		},
 	}
 	return service
@@ -810,6 +1632,32 @@ func serviceForVaultConfigurer(v *vaultv1alpha1.Vault) *corev1.Service {
 
 func ingressForVault(v *vaultv1alpha1.Vault) *netv1.Ingress {
 	if ingress := v.GetIngress(); ingress != nil {
+		spec := *ingress.Spec.DeepCopy()
+
+		// Route the standby hosts to the standby service
+		if v.Spec.ServiceRegistrationEnabled {
+			pathType := netv1.PathTypePrefix
+			for _, host := range ingress.StandbyHosts {
+				spec.Rules = append(spec.Rules, netv1.IngressRule{
+					Host: host,
+					IngressRuleValue: netv1.IngressRuleValue{
+						HTTP: &netv1.HTTPIngressRuleValue{
+							Paths: []netv1.HTTPIngressPath{{
+								Path:     "/",
+								PathType: &pathType,
+								Backend: netv1.IngressBackend{
+									Service: &netv1.IngressServiceBackend{
+										Name: v.StandbyServiceName(),
+										Port: netv1.ServiceBackendPort{Number: v.Spec.GetAPIPort()},
+									},
+								},
+							}},
+						},
+					},
+				})
+			}
+		}
+
 		return &netv1.Ingress{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:        v.Name,
@@ -817,7 +1665,7 @@ func ingressForVault(v *vaultv1alpha1.Vault) *netv1.Ingress {
 				Annotations: ingress.Annotations,
 				Labels:      v.LabelsForVault(),
 			},
-			Spec: ingress.Spec,
+			Spec: spec,
 		}
 	}
 	return nil
@@ -845,6 +1693,10 @@ func deploymentForConfigurer(v *vaultv1alpha1.Vault, configmaps corev1.ConfigMap
 	volumeMounts := []corev1.VolumeMount{}
 	configArgs := []string{}
 
+	// configSources collects the content of every mounted config source by kind/name/key,
+	// so the configurer Pod gets rolled when any of them change
+	configSources := map[string]string{}
+
 	sort.Slice(configmaps.Items, func(i, j int) bool { return configmaps.Items[i].Name < configmaps.Items[j].Name })
 	sort.Slice(secrets.Items, func(i, j int) bool { return secrets.Items[i].Name < secrets.Items[j].Name })
 
@@ -869,6 +1721,13 @@ func deploymentForConfigurer(v *vaultv1alpha1.Vault, configmaps corev1.ConfigMap
 
 				configArgs = append(configArgs, "--vault-config-file", "/config/"+cm.Name+"/"+fileName)
 
+				for key, value := range cm.Data {
+					configSources["configmap/"+cm.Name+"/"+key] = value
+				}
+				for key, value := range cm.BinaryData {
+					configSources["configmap/"+cm.Name+"/"+key] = string(value)
+				}
+
 				break
 			}
 		}
@@ -893,6 +1752,10 @@ func deploymentForConfigurer(v *vaultv1alpha1.Vault, configmaps corev1.ConfigMap
 
 				configArgs = append(configArgs, "--vault-config-file", "/config/"+secret.Name+"/"+fileName)
 
+				for key, value := range secret.Data {
+					configSources["secret/"+secret.Name+"/"+key] = string(value)
+				}
+
 				break
 			}
 		}
@@ -917,10 +1780,10 @@ func deploymentForConfigurer(v *vaultv1alpha1.Vault, configmaps corev1.ConfigMap
 	}
 
 	podSpec := corev1.PodSpec{
-		ServiceAccountName:           v.Spec.GetServiceAccount(),
+		ServiceAccountName:           v.GetServiceAccountName(),
 		AutomountServiceAccountToken: ptr.To(true),
 
-		Containers: []corev1.Container{
+		Containers: withKeyDecryptorContainer(v, true, []corev1.Container{
 			{
 				Image:           v.Spec.GetBankVaultsImage(),
 				ImagePullPolicy: corev1.PullIfNotPresent,
@@ -932,13 +1795,13 @@ func deploymentForConfigurer(v *vaultv1alpha1.Vault, configmaps corev1.ConfigMap
 					ContainerPort: 9091,
 					Protocol:      "TCP",
 				}},
-				Env:          withNamespaceEnv(v, withCommonEnv(v, withTLSEnv(v, false, withCredentialsEnv(v, []corev1.EnvVar{})))),
-				VolumeMounts: withHSMVolumeMount(v, withTLSVolumeMount(v, withCredentialsVolumeMount(v, volumeMounts))),
+				Env:          withUnsealSecretEnv(v, withNamespaceEnv(v, withCommonEnv(v, withTLSEnv(v, false, withCredentialsEnv(v, []corev1.EnvVar{}))))),
+				VolumeMounts: withDecryptedKeysVolumeMount(v, withUnsealSecretVolumeMount(v, withHSMVolumeMount(v, withTLSVolumeMount(v, withCredentialsVolumeMount(v, volumeMounts))))),
 				WorkingDir:   "/config",
 				Resources:    getBankVaultsResource(v),
 			},
-		},
-		Volumes:         withHSMVolume(v, withTLSVolume(v, withCredentialsVolume(v, volumes))),
+		}),
+		Volumes:         withDecryptedKeysVolume(v, withUnsealSecretVolume(v, withHSMVolume(v, withTLSVolume(v, withCredentialsVolume(v, volumes))))),
 		SecurityContext: withPodSecurityContext(v),
 		NodeSelector:    v.Spec.NodeSelector,
 		Tolerations:     v.Spec.Tolerations,
@@ -953,6 +1816,15 @@ func deploymentForConfigurer(v *vaultv1alpha1.Vault, configmaps corev1.ConfigMap
 		}
 	}
 
+	// The JSON encoding sorts the sources and escapes their content, so the sum is stable and unambiguous
+	configSourcesJSON, err := json.Marshal(configSources)
+	if err != nil {
+		return nil, err
+	}
+
+	podAnnotations := withVaultConfigurerAnnotations(v, withPrometheusAnnotations("9091", withRestartAnnotations(tlsAnnotations, map[string]string{})))
+	podAnnotations["vault.banzaicloud.io/configurer-config-sum"] = fmt.Sprintf("%x", sha256.Sum256(configSourcesJSON))
+
 	dep := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        v.Name + "-configurer",
@@ -968,7 +1840,7 @@ func deploymentForConfigurer(v *vaultv1alpha1.Vault, configmaps corev1.ConfigMap
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      withVaultConfigurerLabels(v, ls),
-					Annotations: withVaultConfigurerAnnotations(v, withPrometheusAnnotations("9091", tlsAnnotations)),
+					Annotations: podAnnotations,
 				},
 				Spec: podSpec,
 			},
@@ -1004,30 +1876,91 @@ func hostsForService(svc, namespace string) []string {
 	return []string{
 		svc,
 		svc + "." + namespace,
-		svc + "." + namespace + ".svc.cluster.local",
+		serviceFQDN(svc, namespace),
+	}
+}
+
+// serviceFQDN returns the fully qualified domain name of a Service
+func serviceFQDN(svc, namespace string) string {
+	return svc + "." + namespace + ".svc." + ClusterDomain
+}
+
+// DetectClusterDomain looks up the cluster domain through the DNS name of the Kubernetes API Service
+func DetectClusterDomain() (string, error) {
+	const apiService = "kubernetes.default.svc."
+
+	cname, err := net.LookupCNAME("kubernetes.default.svc")
+	if err != nil {
+		return "", fmt.Errorf("failed to look up the Kubernetes API Service: %v", err)
+	}
+
+	domain := strings.TrimSuffix(strings.TrimPrefix(cname, apiService), ".")
+	if !strings.HasPrefix(cname, apiService) || domain == "" {
+		return "", fmt.Errorf("unexpected canonical name of the Kubernetes API Service: %s", cname)
 	}
+
+	return domain, nil
+}
+
+// hostOrIP strips the brackets of an IPv6 address, so it ends up in the IP SANs of the certificate
+func hostOrIP(host string) string {
+	if ip := net.ParseIP(strings.Trim(host, "[]")); ip != nil {
+		return ip.String()
+	}
+	return host
 }
 
 func hostsAndIPsForVault(v *vaultv1alpha1.Vault, service *corev1.Service) []string {
-	hostsAndIPs := []string{"127.0.0.1"}
+	return hostsAndIPsForPods(v, service, v.Status.PodIPs)
+}
+
+func hostsAndIPsForPods(v *vaultv1alpha1.Vault, service *corev1.Service, podIPs []string) []string {
+	hostsAndIPs := []string{"127.0.0.1", "::1"}
 
 	hostsAndIPs = append(hostsAndIPs, hostsForService(v.Name, v.Namespace)...)
+	if v.Spec.ServiceRegistrationEnabled {
+		hostsAndIPs = append(hostsAndIPs, hostsForService(v.ActiveServiceName(), v.Namespace)...)
+		hostsAndIPs = append(hostsAndIPs, hostsForService(v.StandbyServiceName(), v.Namespace)...)
+	}
 	hostsAndIPs = append(hostsAndIPs, loadBalancerIngressPoints(service)...)
 
 	// Add additional TLS hosts from the Vault Spec
 	for _, additionalHost := range v.Spec.TLSAdditionalHosts {
 		if additionalHost != "" {
-			hostsAndIPs = append(hostsAndIPs, additionalHost)
+			hostsAndIPs = append(hostsAndIPs, hostOrIP(additionalHost))
 		}
 	}
 
-	if v.Spec.Size > 1 {
+	// Add the Gateway API route hostnames if TLS is passed through to Vault
+	if v.Spec.Gateway != nil && v.Spec.Gateway.IsTLSRoute() {
+		for _, hostname := range slices.Concat(v.GetGatewayHostnames(), v.Spec.Gateway.StandbyHostnames) {
+			if !slices.Contains(hostsAndIPs, hostname) {
+				hostsAndIPs = append(hostsAndIPs, hostname)
+			}
+		}
+	}
+
+	for i := 0; i < int(v.Spec.Size); i++ {
+		podName := perInstanceVaultServiceName(v.Name, i)
+		hostsAndIPs = append(hostsAndIPs,
+			podName+"."+headlessServiceName(v)+"."+v.Namespace+".svc",
+			podFQDN(v, podName))
+	}
+
+	if v.Spec.Size > 1 && !v.Spec.PerInstanceServicesDisabled {
 		for i := 0; i < int(v.Spec.Size); i++ {
 			hostsAndIPs = append(hostsAndIPs,
 				hostsForService(perInstanceVaultServiceName(v.Name, i), v.Namespace)...)
 		}
 	}
 
+	// Add the IPs of the Vault pods, as last recorded in the status
+	for _, ip := range podIPs {
+		if !slices.Contains(hostsAndIPs, ip) {
+			hostsAndIPs = append(hostsAndIPs, ip)
+		}
+	}
+
 	return hostsAndIPs
 }
 
@@ -1103,6 +2036,9 @@ func populateTLSSecret(v *vaultv1alpha1.Vault, service *corev1.Service, secret *
 	secret.StringData["ca.key"] = certMgr.Chain.CAKey
 	secret.StringData["server.crt"] = certMgr.Chain.ServerCert
 	secret.StringData["server.key"] = certMgr.Chain.ServerKey
+	if len(v.Status.PodIPs) > 0 {
+		secret.Annotations[tlsPodIPsAnnotation] = strings.Join(v.Status.PodIPs, ",")
+	}
 
 	tlsExpiration, err := bvtls.GetCertExpirationDate([]byte(certMgr.Chain.ServerCert))
 	if err != nil {
@@ -1144,7 +2080,7 @@ func statefulSetForVault(v *vaultv1alpha1.Vault, externalSecretsToWatchItems []c
 		},
 	}))
 
-	volumes = withHSMVolume(v, withStatsdVolume(v, withAuditLogVolume(v, volumes)))
+	volumes = withDecryptedKeysVolume(v, withTransitUnsealVolume(v, withUnsealSecretVolume(v, withHSMVolume(v, withStatsdVolume(v, withAuditLogVolume(v, volumes))))))
 
 	volumeMounts := withTLSVolumeMount(v, withCredentialsVolumeMount(v, []corev1.VolumeMount{
 		{
@@ -1153,16 +2089,20 @@ func statefulSetForVault(v *vaultv1alpha1.Vault, externalSecretsToWatchItems []c
 		},
 	}))
 
-	volumeMounts = withAuditLogVolumeMount(v, volumeMounts)
+	volumeMounts = withTransitUnsealVolumeMount(v, withAuditLogVolumeMount(v, volumeMounts))
 
+	// The operator initialises a Vault with encrypted keys
 	unsealCommand := []string{"bank-vaults", "unseal", "--init"}
+	if v.Spec.UnsealConfig.IsEncrypted() {
+		unsealCommand = []string{"bank-vaults", "unseal"}
+	}
 
 	if v.Spec.IsAutoUnseal() {
 		unsealCommand = append(unsealCommand, "--auto")
 	}
 
 	if v.Spec.IsRaftStorage() {
-		raftLeaderAddress := v.Name
+		raftLeaderAddress := serviceFQDN(v.Name, v.Namespace)
 		raftApiScheme := v.Spec.GetAPIScheme()
 		if v.Spec.IsRaftBootstrapFollower() {
 			raftLeaderAddress = v.Spec.RaftLeaderAddress
@@ -1171,7 +2111,7 @@ func statefulSetForVault(v *vaultv1alpha1.Vault, externalSecretsToWatchItems []c
 			}
 		}
 
-		unsealCommand = append(unsealCommand, "--raft", "--raft-leader-address", raftApiScheme+"://"+raftLeaderAddress+":8200")
+		unsealCommand = append(unsealCommand, "--raft", "--raft-leader-address", raftApiScheme+"://"+net.JoinHostPort(hostOrIP(raftLeaderAddress), apiPort(v)))
 
 		if v.Spec.IsRaftBootstrapFollower() {
 			unsealCommand = append(unsealCommand, "--raft-secondary")
@@ -1189,7 +2129,7 @@ func statefulSetForVault(v *vaultv1alpha1.Vault, externalSecretsToWatchItems []c
 			Name:            "vault",
 			Args:            []string{"server"},
 			Ports:           containerPorts,
-			Env: withClusterAddr(v, service, withCredentialsEnv(v, withVaultEnv(v, []corev1.EnvVar{
+			Env: withClusterAddr(v, service, withSealEnv(v, withCredentialsEnv(v, withVaultEnv(v, []corev1.EnvVar{
 				{
 					Name: "VAULT_K8S_POD_NAME",
 					ValueFrom: &corev1.EnvVarSource{
@@ -1198,7 +2138,7 @@ func statefulSetForVault(v *vaultv1alpha1.Vault, externalSecretsToWatchItems []c
 						},
 					},
 				},
-			}))),
+			})))),
 			SecurityContext: withContainerSecurityContext(v),
 			// This probe allows Vault extra time to be responsive in a HTTPS manner during startup
 			// See: https://www.vaultproject.io/api/system/init.html
@@ -1246,7 +2186,7 @@ func statefulSetForVault(v *vaultv1alpha1.Vault, externalSecretsToWatchItems []c
 			Name:            "bank-vaults",
 			Command:         unsealCommand,
 			Args:            v.Spec.UnsealConfig.ToArgs(v),
-			Env: withSidecarEnv(v, withTLSEnv(v, true, withCredentialsEnv(v, withCommonEnv(v, []corev1.EnvVar{
+			Env: withUnsealSecretEnv(v, withSidecarEnv(v, withTLSEnv(v, true, withCredentialsEnv(v, withCommonEnv(v, []corev1.EnvVar{
 				{
 					Name: "POD_NAME",
 					ValueFrom: &corev1.EnvVarSource{
@@ -1255,16 +2195,24 @@ func statefulSetForVault(v *vaultv1alpha1.Vault, externalSecretsToWatchItems []c
 						},
 					},
 				},
-			})))),
+			}))))),
 			Ports: []corev1.ContainerPort{{
 				Name:          "metrics",
 				ContainerPort: 9091,
 				Protocol:      "TCP",
 			}},
-			VolumeMounts: withHSMVolumeMount(v, withBanksVaultsVolumeMounts(v, withTLSVolumeMount(v, withCredentialsVolumeMount(v, []corev1.VolumeMount{})))),
+			VolumeMounts: withDecryptedKeysVolumeMount(v, withUnsealSecretVolumeMount(v, withHSMVolumeMount(v, withBanksVaultsVolumeMounts(v, withTLSVolumeMount(v, withCredentialsVolumeMount(v, []corev1.VolumeMount{})))))),
 			Resources:    getBankVaultsResource(v),
 		},
 	})))
+	containers = withKeyDecryptorContainer(v, false, containers)
+
+	// The operator initialises and unseals Vault, the unsealer would initialise it with its own plain keys,
+	// and the unsealer would unseal Vault again during an emergency seal, auto-unsealed pods are kept as they are,
+	// they would unseal themselves when restarted
+	if v.Spec.UnsealConfig.OperatorUnseals() || (v.IsEmergencySealed() && !v.Spec.IsAutoUnseal()) {
+		containers = slices.DeleteFunc(containers, func(c corev1.Container) bool { return c.Name == "bank-vaults" || c.Name == "key-decryptor" })
+	}
 
 	if v.Spec.UnsealConfig.HSMDaemonNeeded() {
 		containers = append(containers, corev1.Container{
@@ -1293,7 +2241,7 @@ func statefulSetForVault(v *vaultv1alpha1.Vault, externalSecretsToWatchItems []c
 	podSpec := corev1.PodSpec{
 		Affinity: affinity,
 
-		ServiceAccountName:           v.Spec.GetServiceAccount(),
+		ServiceAccountName:           v.GetServiceAccountName(),
 		AutomountServiceAccountToken: ptr.To(true),
 
 		InitContainers: withVaultInitContainers(v, []corev1.Container{
@@ -1341,6 +2289,18 @@ func statefulSetForVault(v *vaultv1alpha1.Vault, externalSecretsToWatchItems []c
 		return nil, err
 	}
 
+	// Pods are restarted one at a time by the operator during a seal migration
+	updateStrategy := appsv1.StatefulSetUpdateStrategy{
+		Type: appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+			Partition: new(int32),
+		},
+	}
+	// and during an emergency seal, a rolling update would wait for the sealed pods to become ready
+	if v.Status.SealMigration.InProgress() || (v.IsEmergencySealed() && !v.Spec.IsAutoUnseal()) {
+		updateStrategy = appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType}
+	}
+
 	podManagementPolicy := appsv1.ParallelPodManagement
 	if v.Spec.IsRaftStorage() || v.Spec.IsRaftHAStorage() {
 		podManagementPolicy = appsv1.OrderedReadyPodManagement
@@ -1354,13 +2314,9 @@ func statefulSetForVault(v *vaultv1alpha1.Vault, externalSecretsToWatchItems []c
 			Labels:      withVaultLabels(v, ls),
 		},
 		Spec: appsv1.StatefulSetSpec{
-			Replicas: &replicas,
-			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
-				Type: appsv1.RollingUpdateStatefulSetStrategyType,
-				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
-					Partition: new(int32),
-				},
-			},
+			ServiceName:         headlessServiceName(v),
+			Replicas:            &replicas,
+			UpdateStrategy:      updateStrategy,
 			PodManagementPolicy: podManagementPolicy,
 			Selector: &metav1.LabelSelector{
 				MatchLabels: ls,
@@ -1472,15 +2428,16 @@ func withRestartAnnotations(restartAnnotations, annotations map[string]string) m
 
 // TLS Functions
 func withTLSEnv(v *vaultv1alpha1.Vault, localhost bool, envs []corev1.EnvVar) []corev1.EnvVar {
-	host := fmt.Sprintf("%s.%s", v.Name, v.Namespace)
+	host := serviceFQDN(v.Name, v.Namespace)
 	if localhost {
 		host = "127.0.0.1"
 	}
+	address := net.JoinHostPort(host, apiPort(v))
 	if !v.Spec.IsTLSDisabled() {
 		envs = append(envs, []corev1.EnvVar{
 			{
 				Name:  api.EnvVaultAddress,
-				Value: fmt.Sprintf("https://%s:8200", host),
+				Value: "https://" + address,
 			},
 			{
 				Name:  api.EnvVaultCACert,
@@ -1490,7 +2447,7 @@ func withTLSEnv(v *vaultv1alpha1.Vault, localhost bool, envs []corev1.EnvVar) []
 	} else {
 		envs = append(envs, corev1.EnvVar{
 			Name:  api.EnvVaultAddress,
-			Value: fmt.Sprintf("http://%s:8200", host),
+			Value: "http://" + address,
 		})
 	}
 	return envs
@@ -1612,17 +2569,38 @@ func withClusterAddr(v *vaultv1alpha1.Vault, service *corev1.Service, envs []cor
 	if value != "" && v.Spec.RaftLeaderAddress != "" {
 		envs = append(envs, corev1.EnvVar{
 			Name:  "VAULT_CLUSTER_ADDR",
-			Value: "https://" + value + ":8201",
+			Value: "https://" + net.JoinHostPort(value, clusterPort(v)),
+		})
+		envs = append(envs, corev1.EnvVar{
+			Name:  "VAULT_API_ADDR",
+			Value: v.Spec.GetAPIScheme() + "://" + net.JoinHostPort(value, apiPort(v)),
+		})
+		return envs
+	}
+
+	// Otherwise advertise the pod DNS name in the headless Service, if not configured explicitly
+	config := v.Spec.GetVaultConfig()
+	podAddress := podFQDN(v, "$(VAULT_K8S_POD_NAME)")
+	if _, ok := config["cluster_addr"]; !ok && !hasEnv(envs, "VAULT_CLUSTER_ADDR") {
+		envs = append(envs, corev1.EnvVar{
+			Name:  "VAULT_CLUSTER_ADDR",
+			Value: "https://" + net.JoinHostPort(podAddress, clusterPort(v)),
 		})
+	}
+	if _, ok := config["api_addr"]; !ok && !hasEnv(envs, "VAULT_API_ADDR") {
 		envs = append(envs, corev1.EnvVar{
 			Name:  "VAULT_API_ADDR",
-			Value: v.Spec.GetAPIScheme() + "://" + value + ":8200",
+			Value: v.Spec.GetAPIScheme() + "://" + net.JoinHostPort(podAddress, apiPort(v)),
 		})
 	}
 
 	return envs
 }
 
+func hasEnv(envs []corev1.EnvVar, name string) bool {
+	return slices.ContainsFunc(envs, func(env corev1.EnvVar) bool { return env.Name == name })
+}
+
 func withCredentialsVolume(v *vaultv1alpha1.Vault, volumes []corev1.Volume) []corev1.Volume {
 	secretName := v.Spec.CredentialsConfig.SecretName
 	if secretName != "" {
@@ -1812,6 +2790,101 @@ func withHSMVolumeMount(v *vaultv1alpha1.Vault, volumeMounts []corev1.VolumeMoun
 	return volumeMounts
 }
 
+// withUnsealSecretEnv passes the HSM pin to bank-vaults from a Secret, instead of the command line
+func withUnsealSecretEnv(v *vaultv1alpha1.Vault, envs []corev1.EnvVar) []corev1.EnvVar {
+	if hsm := v.Spec.UnsealConfig.HSM; hsm != nil && hsm.PinSecretRef != nil {
+		envs = append(envs, corev1.EnvVar{
+			Name:      "HSM_PIN",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: hsm.PinSecretRef},
+		})
+	}
+	return envs
+}
+
+// withSealEnv passes the token of the unsealing Vault to the transit seal rendered by the operator
+func withSealEnv(v *vaultv1alpha1.Vault, envs []corev1.EnvVar) []corev1.EnvVar {
+	if v.Spec.UnsealConfig.VaultRef != nil && !hasEnv(envs, "VAULT_TOKEN") {
+		return append(envs, corev1.EnvVar{
+			Name: "VAULT_TOKEN",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: v.TransitUnsealSecretName()},
+				Key:                  "token",
+			}},
+		})
+	}
+
+	unsealVault := v.Spec.UnsealConfig.Vault
+	if unsealVault == nil || v.Spec.GetRenderedSeal() == nil || v.Spec.GetSealType() != "transit" || hasEnv(envs, "VAULT_TOKEN") {
+		return envs
+	}
+
+	if unsealVault.TokenSecretRef != nil {
+		envs = append(envs, corev1.EnvVar{
+			Name:      "VAULT_TOKEN",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: unsealVault.TokenSecretRef},
+		})
+	} else if unsealVault.Token != "" {
+		envs = append(envs, corev1.EnvVar{Name: "VAULT_TOKEN", Value: unsealVault.Token})
+	}
+	return envs
+}
+
+// withUnsealSecretVolume mounts the token of the unsealing Vault from a Secret, instead of the command line
+func withUnsealSecretVolume(v *vaultv1alpha1.Vault, volumes []corev1.Volume) []corev1.Volume {
+	if unsealVault := v.Spec.UnsealConfig.Vault; unsealVault != nil && unsealVault.TokenSecretRef != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: "vault-unseal-token",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: unsealVault.TokenSecretRef.Name,
+					Items:      []corev1.KeyToPath{{Key: unsealVault.TokenSecretRef.Key, Path: "token"}},
+					Optional:   unsealVault.TokenSecretRef.Optional,
+				},
+			},
+		})
+	}
+	return volumes
+}
+
+func withUnsealSecretVolumeMount(v *vaultv1alpha1.Vault, volumeMounts []corev1.VolumeMount) []corev1.VolumeMount {
+	if unsealVault := v.Spec.UnsealConfig.Vault; unsealVault != nil && unsealVault.TokenSecretRef != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "vault-unseal-token",
+			MountPath: vaultv1alpha1.VaultUnsealTokenMountPath,
+			ReadOnly:  true,
+		})
+	}
+	return volumeMounts
+}
+
+// withTransitUnsealVolume mounts the CA of the Vault referenced for transit auto-unseal
+func withTransitUnsealVolume(v *vaultv1alpha1.Vault, volumes []corev1.Volume) []corev1.Volume {
+	if v.Spec.UnsealConfig.VaultRef != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: "vault-transit-unseal",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: v.TransitUnsealSecretName(),
+					Items:      []corev1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}},
+					Optional:   ptr.To(true),
+				},
+			},
+		})
+	}
+	return volumes
+}
+
+func withTransitUnsealVolumeMount(v *vaultv1alpha1.Vault, volumeMounts []corev1.VolumeMount) []corev1.VolumeMount {
+	if v.Spec.UnsealConfig.VaultRef != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "vault-transit-unseal",
+			MountPath: vaultv1alpha1.VaultRefUnsealMountPath,
+			ReadOnly:  true,
+		})
+	}
+	return volumeMounts
+}
+
 func getPodAntiAffinity(v *vaultv1alpha1.Vault) *corev1.PodAntiAffinity {
 	if v.Spec.PodAntiAffinity == "" {
 		return nil
@@ -2002,6 +3075,20 @@ func podList() *corev1.PodList {
 }
 
 // getPodNames returns the pod names of the array of pods passed in
+// getPodIPs returns the sorted IPs of the pods
+func getPodIPs(pods []corev1.Pod) []string {
+	var podIPs []string
+	for _, pod := range pods {
+		for _, podIP := range pod.Status.PodIPs {
+			if podIP.IP != "" {
+				podIPs = append(podIPs, podIP.IP)
+			}
+		}
+	}
+	sort.Strings(podIPs)
+	return podIPs
+}
+
 func getPodNames(pods []corev1.Pod) []string {
 	podNames := []string{}
 	for _, pod := range pods {
@@ -2164,9 +3251,40 @@ func (r *ReconcileVault) distributeCACertificate(ctx context.Context, v *vaultv1
 	return nil
 }
 
-func certHostsAndIPsChanged(v *vaultv1alpha1.Vault, service *corev1.Service, cert *x509.Certificate) bool {
-	// TODO very weak check for now
-	return len(cert.DNSNames)+len(cert.IPAddresses) != len(hostsAndIPsForVault(v, service))
+// certHostsAndIPsChanged compares the SANs of the certificate with the hosts and IPs of the Vault,
+// taking the pod IPs the certificate was generated with
+func certHostsAndIPsChanged(v *vaultv1alpha1.Vault, service *corev1.Service, cert *x509.Certificate, certPodIPs []string) bool {
+	certHosts := slices.Clone(cert.DNSNames)
+	for _, ip := range cert.IPAddresses {
+		certHosts = append(certHosts, ip.String())
+	}
+	var hosts []string
+	for _, host := range hostsAndIPsForPods(v, service, certPodIPs) {
+		if ip := net.ParseIP(host); ip != nil {
+			host = ip.String()
+		}
+		hosts = append(hosts, host)
+	}
+	slices.Sort(certHosts)
+	slices.Sort(hosts)
+	return !slices.Equal(slices.Compact(certHosts), slices.Compact(hosts))
+}
+
+// podIPsOfTLSSecret returns the pod IPs the certificate of the TLS Secret was generated with
+func podIPsOfTLSSecret(secret *corev1.Secret) []string {
+	if secret.Annotations[tlsPodIPsAnnotation] == "" {
+		return nil
+	}
+	return strings.Split(secret.Annotations[tlsPodIPsAnnotation], ",")
+}
+
+// withTLSRestartDate records on the TLS Secret the expiration date the Vault pods were last restarted for
+func withTLSRestartDate(annotations map[string]string, restartDate string) map[string]string {
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[tlsExpirationAnnotation] = restartDate
+	return annotations
 }
 
 func (r *ReconcileVault) deployConfigurer(ctx context.Context, v *vaultv1alpha1.Vault, tlsAnnotations map[string]string) error {
@@ -2252,11 +3370,7 @@ func (r *ReconcileVault) handleStorageConfiguration(ctx context.Context, v *vaul
 		}
 
 		// Update Vault's status with the new condition
-		v.Status = vaultv1alpha1.VaultStatus{
-			Nodes:      v.Status.Nodes,
-			Leader:     v.Status.Leader,
-			Conditions: []corev1.ComponentCondition{condition},
-		}
+		v.Status.SetCondition(condition)
 
 		// Update Kubernetes with the new Vault status
 		err := r.client.Status().Update(ctx, v)
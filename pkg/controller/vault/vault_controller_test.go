@@ -16,15 +16,27 @@ package vault
 
 import (
 	"context"
-	"net/http"
+	"sort"
 	"testing"
 
 	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	bvtls "github.com/bank-vaults/vault-sdk/tls"
+	"github.com/hashicorp/vault/api"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
 	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -39,6 +51,22 @@ var envs = []corev1.EnvVar{
 	},
 }
 
+// newTestReconciler returns a ReconcileVault backed by a fake client holding the objects
+func newTestReconciler(t *testing.T, objects ...client.Object) (*ReconcileVault, client.Client) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, vaultv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	return &ReconcileVault{
+		client:              c,
+		nonNamespacedClient: c,
+		scheme:              scheme,
+		recorder:            record.NewFakeRecorder(100),
+		httpClients:         newVaultHTTPClientCache(),
+	}, c
+}
+
 func TestFluentDConfFile(t *testing.T) {
 	testFilename := "test.conf"
 
@@ -102,7 +130,6 @@ func TestHandleStorageConfiguration_MissingStorage(t *testing.T) {
 		client:              client,
 		nonNamespacedClient: client,
 		scheme:              client.Scheme(),
-		httpClient:          &http.Client{},
 	}
 
 	err = reconciler.handleStorageConfiguration(context.Background(), vault)
@@ -291,3 +318,504 @@ func TestWithVaultEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestVaultsForWatchedSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, vaultv1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	vaults := []client.Object{
+		&vaultv1alpha1.Vault{
+			ObjectMeta: metav1.ObjectMeta{Name: "by-labels", Namespace: "default"},
+			Spec: vaultv1alpha1.VaultSpec{
+				WatchedSecretsLabels: []map[string]string{{"cert-manager.io/certificate-name": "vault"}},
+			},
+		},
+		&vaultv1alpha1.Vault{
+			ObjectMeta: metav1.ObjectMeta{Name: "by-expression", Namespace: "default"},
+			Spec: vaultv1alpha1.VaultSpec{
+				WatchedSecretsSelectors: []metav1.LabelSelector{{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      "cert-manager.io/certificate-name",
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{"vault", "vault-public"},
+					}},
+				}},
+			},
+		},
+		&vaultv1alpha1.Vault{
+			ObjectMeta: metav1.ObjectMeta{Name: "by-annotation", Namespace: "default"},
+			Spec: vaultv1alpha1.VaultSpec{
+				WatchedSecretsAnnotationsSelectors: []metav1.LabelSelector{{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      "reflector.v1.k8s.emberstack.com/reflects",
+						Operator: metav1.LabelSelectorOpExists,
+					}},
+				}},
+			},
+		},
+		&vaultv1alpha1.Vault{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-namespace", Namespace: "other"},
+			Spec: vaultv1alpha1.VaultSpec{
+				WatchedSecretsLabels: []map[string]string{{"cert-manager.io/certificate-name": "vault"}},
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(vaults...).Build()
+	mapFunc := vaultsForWatchedSecret(c)
+
+	tests := []struct {
+		name     string
+		secret   *corev1.Secret
+		expected []string
+	}{
+		{
+			name: "exact and set-based label match",
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name:      "vault-tls-public",
+				Namespace: "default",
+				Labels:    map[string]string{"cert-manager.io/certificate-name": "vault"},
+			}},
+			expected: []string{"by-expression", "by-labels"},
+		},
+		{
+			name: "set-based label match only",
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name:      "vault-tls-public",
+				Namespace: "default",
+				Labels:    map[string]string{"cert-manager.io/certificate-name": "vault-public"},
+			}},
+			expected: []string{"by-expression"},
+		},
+		{
+			name: "annotation match",
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name:        "reflected",
+				Namespace:   "default",
+				Annotations: map[string]string{"reflector.v1.k8s.emberstack.com/reflects": "certs/vault"},
+			}},
+			expected: []string{"by-annotation"},
+		},
+		{
+			name: "no match",
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name:      "unrelated",
+				Namespace: "default",
+				Labels:    map[string]string{"app": "unrelated"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		ttp := tt
+		t.Run(ttp.name, func(t *testing.T) {
+			var names []string
+			for _, request := range mapFunc(context.Background(), ttp.secret) {
+				assert.Equal(t, ttp.secret.Namespace, request.Namespace)
+				names = append(names, request.Name)
+			}
+			sort.Strings(names)
+			assert.Equal(t, ttp.expected, names)
+		})
+	}
+}
+
+func TestDeploymentForConfigurerConfigSum(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+	}
+
+	configSum := func(configmaps corev1.ConfigMapList, secrets corev1.SecretList) string {
+		dep, err := deploymentForConfigurer(v, configmaps, secrets, map[string]string{})
+		require.NoError(t, err)
+		return dep.Spec.Template.Annotations["vault.banzaicloud.io/configurer-config-sum"]
+	}
+
+	configmaps := corev1.ConfigMapList{Items: []corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: "external-config"},
+		Data:       map[string]string{"vault-config.yml": "policies: []"},
+	}}}
+	secrets := corev1.SecretList{Items: []corev1.Secret{{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-configurer"},
+		Data:       map[string][]byte{"vault-config.yml": []byte("auth: []")},
+	}}}
+
+	original := configSum(configmaps, secrets)
+	assert.NotEmpty(t, original)
+	assert.Equal(t, original, configSum(configmaps, secrets), "config sum should be stable")
+
+	configmaps.Items[0].Data["vault-config.yml"] = "policies: [{name: allow_secrets}]"
+	changedConfigMap := configSum(configmaps, secrets)
+	assert.NotEqual(t, original, changedConfigMap, "config sum should change with the ConfigMap content")
+
+	secrets.Items[0].Data["vault-config.yml"] = []byte("auth: [{type: kubernetes}]")
+	assert.NotEqual(t, changedConfigMap, configSum(configmaps, secrets), "config sum should change with the Secret content")
+
+	// Sources which are not mounted do not affect the sum
+	unmounted := corev1.ConfigMapList{Items: append(configmaps.Items, corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "unrelated"},
+		Data:       map[string]string{"other.yml": "foo"},
+	})}
+	assert.Equal(t, configSum(configmaps, secrets), configSum(unmounted, secrets))
+
+	// The content of a source can't pass for another source
+	merged := corev1.ConfigMapList{Items: []corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: "external-config"},
+		Data:       map[string]string{"vault-config.yml": "a;;configmap/external-config/z.yml=b"},
+	}}}
+	split := corev1.ConfigMapList{Items: []corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: "external-config"},
+		Data:       map[string]string{"vault-config.yml": "a", "z.yml": "b"},
+	}}}
+	assert.NotEqual(t, configSum(merged, secrets), configSum(split, secrets))
+}
+
+func TestHeadlessServiceForVault(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size:                        2,
+			PerInstanceServicesDisabled: true,
+			Config:                      extv1beta1.JSON{Raw: []byte(`{"storage": {"raft": {"path": "/vault/file"}}}`)},
+		},
+	}
+
+	service := headlessServiceForVault(v)
+	assert.Equal(t, "vault-headless", service.Name)
+	assert.Equal(t, corev1.ClusterIPNone, service.Spec.ClusterIP)
+	assert.True(t, service.Spec.PublishNotReadyAddresses)
+	assert.Empty(t, perInstanceServicesForVault(v))
+
+	assert.Equal(t, "https://vault-1.vault-headless.vault.svc.cluster.local:8200", podAddressForVault(v, "vault-1"))
+
+	hosts := hostsAndIPsForVault(v, &corev1.Service{})
+	assert.Contains(t, hosts, "vault-0.vault-headless.vault.svc")
+	assert.Contains(t, hosts, "vault-1.vault-headless.vault.svc.cluster.local")
+	assert.NotContains(t, hosts, "vault-1.vault")
+
+	// Existing clusters keep their config unless retry_join is asked for
+	configJSON, err := withRaftRetryJoin(v, v.Spec.Config.Raw)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"storage": {"raft": {"path": "/vault/file"}}}`, string(configJSON))
+
+	v.Spec.RaftRetryJoin = true
+	configJSON, err = withRaftRetryJoin(v, v.Spec.Config.Raw)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"storage": {"raft": {"path": "/vault/file", "retry_join": [
+		{"leader_api_addr": "https://vault-0.vault-headless.vault.svc.cluster.local:8200", "leader_ca_cert_file": "/vault/tls/ca.crt"},
+		{"leader_api_addr": "https://vault-1.vault-headless.vault.svc.cluster.local:8200", "leader_ca_cert_file": "/vault/tls/ca.crt"}
+	]}}}`, string(configJSON))
+
+	envs := withClusterAddr(v, &corev1.Service{}, nil)
+	assert.Contains(t, envs, corev1.EnvVar{Name: "VAULT_API_ADDR", Value: "https://$(VAULT_K8S_POD_NAME).vault-headless.vault.svc.cluster.local:8200"})
+}
+
+func TestClusterDomainAndIPv6(t *testing.T) {
+	defaultClusterDomain := ClusterDomain
+	ClusterDomain = "example.internal"
+	defer func() { ClusterDomain = defaultClusterDomain }()
+
+	dualStack := corev1.IPFamilyPolicyPreferDualStack
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size:               1,
+			TLSAdditionalHosts: []string{"[fd00::10]"},
+			IPFamilyPolicy:     &dualStack,
+		},
+	}
+
+	hosts := hostsAndIPsForVault(v, &corev1.Service{})
+	assert.Contains(t, hosts, "::1")
+	assert.Contains(t, hosts, "fd00::10")
+	assert.Contains(t, hosts, "vault.vault.svc.example.internal")
+	assert.Contains(t, hosts, "vault-0.vault-headless.vault.svc.example.internal")
+
+	assert.Contains(t, withTLSEnv(v, false, nil), corev1.EnvVar{Name: api.EnvVaultAddress, Value: "https://vault.vault.svc.example.internal:8200"})
+
+	service := &corev1.Service{Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{
+		Ingress: []corev1.LoadBalancerIngress{{IP: "2001:db8::1"}},
+	}}}
+	v.Spec.RaftLeaderAddress = "self"
+	assert.Contains(t, withClusterAddr(v, service, nil), corev1.EnvVar{Name: "VAULT_CLUSTER_ADDR", Value: "https://[2001:db8::1]:8201"})
+
+	assert.Equal(t, &dualStack, headlessServiceForVault(v).Spec.IPFamilyPolicy)
+}
+
+func TestTLSCertificatePodIPs(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"},
+		Spec:       vaultv1alpha1.VaultSpec{Size: 2},
+		Status:     vaultv1alpha1.VaultStatus{PodIPs: []string{"10.0.0.5", "fd00::5"}},
+	}
+	service := &corev1.Service{}
+
+	secret := &corev1.Secret{}
+	_, err := populateTLSSecret(v, service, secret)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.5,fd00::5", secret.Annotations[tlsPodIPsAnnotation])
+
+	certificate, err := bvtls.PEMToCertificate([]byte(secret.StringData["server.crt"]))
+	require.NoError(t, err)
+	var ips []string
+	for _, ip := range certificate.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+	assert.ElementsMatch(t, []string{"127.0.0.1", "::1", "10.0.0.5", "fd00::5"}, ips)
+
+	// The hosts are compared with the pod IPs the certificate was generated with
+	assert.False(t, certHostsAndIPsChanged(v, service, certificate, podIPsOfTLSSecret(secret)))
+	v.Status.PodIPs = []string{"10.0.0.6", "fd00::5"}
+	assert.False(t, certHostsAndIPsChanged(v, service, certificate, podIPsOfTLSSecret(secret)))
+	v.Spec.TLSAdditionalHosts = []string{"10.1.0.1"}
+	assert.True(t, certHostsAndIPsChanged(v, service, certificate, podIPsOfTLSSecret(secret)))
+
+	pods := []corev1.Pod{
+		{Status: corev1.PodStatus{PodIPs: []corev1.PodIP{{IP: "10.0.0.7"}, {IP: "fd00::7"}}}},
+		{Status: corev1.PodStatus{PodIPs: []corev1.PodIP{{IP: "10.0.0.6"}}}},
+		{},
+	}
+	assert.Equal(t, []string{"10.0.0.6", "10.0.0.7", "fd00::7"}, getPodIPs(pods))
+}
+
+func TestNonDefaultPorts(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size:   1,
+			Config: extv1beta1.JSON{Raw: []byte(`{"listener": {"tcp": {"address": "0.0.0.0:9200", "tls_disable": true}}}`)},
+		},
+	}
+
+	servicePorts, containerPorts := getServicePorts(v)
+	assert.Equal(t, int32(9200), servicePorts[0].Port)
+	assert.Equal(t, int32(9201), containerPorts[1].ContainerPort)
+
+	assert.Equal(t, "http://vault-0.vault-headless.vault.svc.cluster.local:9200", podAddressForVault(v, "vault-0"))
+	assert.Contains(t, withTLSEnv(v, true, nil), corev1.EnvVar{Name: api.EnvVaultAddress, Value: "http://127.0.0.1:9200"})
+	assert.Contains(t, withClusterAddr(v, &corev1.Service{}, nil),
+		corev1.EnvVar{Name: "VAULT_CLUSTER_ADDR", Value: "https://$(VAULT_K8S_POD_NAME).vault-headless.vault.svc.cluster.local:9201"})
+
+	v.Spec.Ingress = &vaultv1alpha1.Ingress{}
+	assert.Equal(t, int32(9200), v.GetIngress().Spec.DefaultBackend.Service.Port.Number)
+}
+
+func TestRoleServicesForVault(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size:                       3,
+			ServiceRegistrationEnabled: true,
+			ServiceTarget:              vaultv1alpha1.ServiceTargetAll,
+			Ingress:                    &vaultv1alpha1.Ingress{StandbyHosts: []string{"standby.vault.example.com"}},
+			Gateway: &vaultv1alpha1.Gateway{
+				ParentRefs:       []vaultv1alpha1.GatewayParentReference{{Name: "gateway"}},
+				StandbyHostnames: []string{"standby.vault.example.com"},
+			},
+		},
+	}
+
+	assert.NotContains(t, serviceForVault(v).Spec.Selector, "vault-active")
+
+	services := roleServicesForVault(v)
+	require.Len(t, services, 2)
+	assert.Equal(t, "vault-active", services[0].Name)
+	assert.Equal(t, "true", services[0].Spec.Selector["vault-active"])
+	assert.Equal(t, "vault-standby", services[1].Name)
+	assert.Equal(t, "false", services[1].Spec.Selector["vault-active"])
+
+	v.Spec.PerformanceStandbyServiceEnabled = true
+	services = roleServicesForVault(v)
+	assert.Equal(t, "true", services[1].Spec.Selector["vault-perf-standby"])
+	assert.NotContains(t, services[1].Spec.Selector, "vault-active")
+
+	// The Ingress reaches the Services with HTTPS on GKE
+	for _, service := range roleServicesForVault(v) {
+		assert.Equal(t, `{"api-port":"HTTPS"}`, service.Annotations["cloud.google.com/app-protocols"])
+	}
+
+	// The Ingress keeps targeting every instance through the main Service
+	ingress := ingressForVault(v)
+	assert.Equal(t, "vault", ingress.Spec.DefaultBackend.Service.Name)
+	require.Len(t, ingress.Spec.Rules, 1)
+	assert.Equal(t, "standby.vault.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "vault-standby", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+
+	route := standbyRouteForVault(v)
+	require.NotNil(t, route)
+	assert.Equal(t, "vault-standby", route.GetName())
+	backendRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	assert.Equal(t, "vault-standby", backendRefs[0].(map[string]interface{})["backendRefs"].([]interface{})[0].(map[string]interface{})["name"])
+
+	targetRefs, _, _ := unstructured.NestedSlice(backendTLSPolicyForVault(v, true).Object, "spec", "targetRefs")
+	assert.Len(t, targetRefs, 2)
+
+	v.Spec.ServiceTarget = ""
+	v.Spec.Ingress = &vaultv1alpha1.Ingress{}
+	assert.Equal(t, "vault-active", ingressForVault(v).Spec.DefaultBackend.Service.Name)
+}
+
+func TestPodDisruptionBudgetForVault(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size:   1,
+			Config: extv1beta1.JSON{Raw: []byte(`{"storage": {"raft": {"path": "/vault/file"}}}`)},
+		},
+	}
+	assert.False(t, v.Spec.IsPodDisruptionBudgetEnabled())
+
+	v.Spec.Size = 5
+	assert.True(t, v.Spec.IsPodDisruptionBudgetEnabled())
+	pdb := podDisruptionBudgetForVault(v)
+	assert.Equal(t, intstr.FromInt32(2), *pdb.Spec.MaxUnavailable)
+	assert.Equal(t, v.LabelsForVault(), pdb.Spec.Selector.MatchLabels)
+
+	v.Spec.Config = extv1beta1.JSON{Raw: []byte(`{"storage": {"consul": {"path": "vault"}}}`)}
+	assert.Equal(t, intstr.FromInt32(4), *podDisruptionBudgetForVault(v).Spec.MaxUnavailable)
+
+	v.Spec.PodDisruptionBudget = &vaultv1alpha1.PodDisruptionBudget{MinAvailable: ptr.To(intstr.FromString("50%"))}
+	pdb = podDisruptionBudgetForVault(v)
+	assert.Equal(t, intstr.FromString("50%"), *pdb.Spec.MinAvailable)
+	assert.Nil(t, pdb.Spec.MaxUnavailable)
+
+	v.Spec.PodDisruptionBudget.Enabled = ptr.To(false)
+	assert.False(t, v.Spec.IsPodDisruptionBudgetEnabled())
+
+	// A two member Raft cluster has no quorum to preserve, the budget would block every drain
+	v.Spec.Size = 2
+	v.Spec.Config = extv1beta1.JSON{Raw: []byte(`{"storage": {"raft": {"path": "/vault/file"}}}`)}
+	v.Spec.PodDisruptionBudget = nil
+	assert.False(t, v.Spec.IsPodDisruptionBudgetEnabled())
+
+	v.Spec.PodDisruptionBudget = &vaultv1alpha1.PodDisruptionBudget{Enabled: ptr.To(true)}
+	assert.True(t, v.Spec.IsPodDisruptionBudgetEnabled())
+	assert.True(t, v.Spec.IsPodDisruptionBudgetQuorumLost())
+	pdb = podDisruptionBudgetForVault(v)
+	assert.Equal(t, intstr.FromInt32(1), *pdb.Spec.MinAvailable)
+	assert.Nil(t, pdb.Spec.MaxUnavailable)
+}
+
+func TestNetworkPoliciesForVault(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size: 3,
+			NetworkPolicy: &vaultv1alpha1.NetworkPolicy{
+				Enabled: true,
+				APIPeers: []netv1.NetworkPolicyPeer{{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelMetadataName: "ingress-nginx"}},
+				}},
+				MonitoringNamespace: "monitoring",
+			},
+		},
+	}
+
+	defer func(namespace string) { OperatorNamespace = namespace }(OperatorNamespace)
+	OperatorNamespace = "vault-operator"
+
+	policies := networkPoliciesForVault(v)
+	require.Len(t, policies, 2)
+
+	vaultPolicy := policies[0]
+	assert.Equal(t, "vault", vaultPolicy.Name)
+	assert.Equal(t, v.LabelsForVault(), vaultPolicy.Spec.PodSelector.MatchLabels)
+	require.Len(t, vaultPolicy.Spec.Ingress, 3)
+
+	// The cluster port is only open between the Vault pods
+	peerRule := vaultPolicy.Spec.Ingress[0]
+	assert.Equal(t, v.LabelsForVault(), peerRule.From[0].PodSelector.MatchLabels)
+	assert.Equal(t, intstr.FromInt32(8201), *peerRule.Ports[1].Port)
+
+	apiRule := vaultPolicy.Spec.Ingress[1]
+	require.Len(t, apiRule.Ports, 1)
+	assert.Equal(t, intstr.FromInt32(8200), *apiRule.Ports[0].Port)
+	assert.Equal(t, v.LabelsForVaultConfigurer(), apiRule.From[0].PodSelector.MatchLabels)
+	assert.Equal(t, "vault-operator", apiRule.From[1].NamespaceSelector.MatchLabels[corev1.LabelMetadataName])
+	assert.Equal(t, "ingress-nginx", apiRule.From[2].NamespaceSelector.MatchLabels[corev1.LabelMetadataName])
+
+	// The ServiceMonitor scrapes the metrics of Vault on the API port
+	metricsRule := vaultPolicy.Spec.Ingress[2]
+	assert.Equal(t, "monitoring", metricsRule.From[0].NamespaceSelector.MatchLabels[corev1.LabelMetadataName])
+	assert.Equal(t, intstr.FromInt32(8200), *metricsRule.Ports[0].Port)
+
+	assert.Equal(t, "vault-configurer", policies[1].Name)
+	assert.Equal(t, v.LabelsForVaultConfigurer(), policies[1].Spec.PodSelector.MatchLabels)
+}
+
+func TestUnsealSecretRefs(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"},
+		Spec: vaultv1alpha1.VaultSpec{
+			UnsealConfig: vaultv1alpha1.UnsealConfig{
+				HSM: &vaultv1alpha1.HSMUnsealConfig{
+					ModulePath: "/usr/lib/libykcs11.so",
+					KeyLabel:   "bank-vaults",
+					Pin:        "123456",
+				},
+				Vault: &vaultv1alpha1.VaultUnsealConfig{
+					Address:        "https://vault.unseal:8200",
+					UnsealKeysPath: "secret/unseal",
+					Token:          "s.root",
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"unsealConfig.hsm.pin", "unsealConfig.vault.token"}, v.Spec.UnsealConfig.PlaintextSecrets())
+	assert.Empty(t, withUnsealSecretEnv(v, nil))
+	assert.Empty(t, withUnsealSecretVolume(v, nil))
+
+	v.Spec.UnsealConfig.HSM.PinSecretRef = &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "hsm"},
+		Key:                  "pin",
+	}
+	v.Spec.UnsealConfig.Vault.TokenSecretRef = &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "unseal-vault"},
+		Key:                  "root-token",
+	}
+	assert.Empty(t, v.Spec.UnsealConfig.PlaintextSecrets())
+
+	args := v.Spec.UnsealConfig.ToArgs(v)
+	assert.NotContains(t, args, "123456")
+	assert.NotContains(t, args, "s.root")
+	assert.Contains(t, args, vaultv1alpha1.VaultUnsealTokenMountPath+"/token")
+
+	envs := withUnsealSecretEnv(v, nil)
+	require.Len(t, envs, 1)
+	assert.Equal(t, "HSM_PIN", envs[0].Name)
+	assert.Equal(t, "hsm", envs[0].ValueFrom.SecretKeyRef.Name)
+
+	volumes := withUnsealSecretVolume(v, nil)
+	require.Len(t, volumes, 1)
+	assert.Equal(t, "unseal-vault", volumes[0].Secret.SecretName)
+	assert.Equal(t, []corev1.KeyToPath{{Key: "root-token", Path: "token"}}, volumes[0].Secret.Items)
+
+	mounts := withUnsealSecretVolumeMount(v, nil)
+	require.Len(t, mounts, 1)
+	assert.Equal(t, vaultv1alpha1.VaultUnsealTokenMountPath, mounts[0].MountPath)
+}
+
+func TestStatefulSetServiceNameChange(t *testing.T) {
+	ctx := context.Background()
+	current := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"},
+		Spec:       appsv1.StatefulSetSpec{ServiceName: "vault"},
+	}
+	r, c := newTestReconciler(t, current)
+
+	// The StatefulSet is deleted and created on a later reconcile, once the delete has finished
+	statefulSet := current.DeepCopy()
+	statefulSet.ResourceVersion = ""
+	statefulSet.Spec.ServiceName = "vault-headless"
+	err := r.createOrUpdateObject(ctx, statefulSet.DeepCopy())
+	require.ErrorIs(t, err, errStatefulSetRecreating)
+	err = c.Get(ctx, client.ObjectKeyFromObject(current), &appsv1.StatefulSet{})
+	require.True(t, apierrors.IsNotFound(err))
+
+	require.NoError(t, r.createOrUpdateObject(ctx, statefulSet))
+	got := &appsv1.StatefulSet{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(current), got))
+	assert.Equal(t, "vault-headless", got.Spec.ServiceName)
+}
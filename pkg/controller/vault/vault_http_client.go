@@ -0,0 +1,220 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// vaultHTTPClientCache caches one HTTP client per Vault, so the connections to its pods are reused across reconciles
+type vaultHTTPClientCache struct {
+	sync.Mutex
+	clients map[types.NamespacedName]vaultHTTPClient
+}
+
+type vaultHTTPClient struct {
+	// tlsHash is the hash of the TLS settings the client was built with, a new CA builds a new client
+	tlsHash string
+	client  *http.Client
+	// certificate is the certificate of an existing TLS Secret, which has to be valid for the pod addresses
+	certificate *x509.Certificate
+	// operatorToken is the token of the last login with the operator identity
+	operatorToken *operatorToken
+}
+
+// operatorToken is a token of the operator identity, it is only used with the identity it was issued for
+type operatorToken struct {
+	identityHash string
+	token        string
+	renewable    bool
+	ttl          time.Duration
+	// expiry is zero if the token doesn't expire
+	expiry time.Time
+}
+
+func newVaultHTTPClientCache() *vaultHTTPClientCache {
+	return &vaultHTTPClientCache{clients: map[types.NamespacedName]vaultHTTPClient{}}
+}
+
+// newVaultClient returns an unauthenticated Vault client of the address, which verifies the Vault TLS certificates
+// with the CA of the Vault unless InsecureSkipTLSVerify is set
+func (cache *vaultHTTPClientCache) newVaultClient(ctx context.Context, c client.Client, v *vaultv1alpha1.Vault, address string) (*api.Client, error) {
+	httpClient, err := cache.httpClientForVault(ctx, c, v)
+	if err != nil {
+		return nil, err
+	}
+
+	// The pod addresses are missing from the SANs of certificates which are not generated by the operator
+	if httpClient.certificate != nil {
+		addressURL, err := url.Parse(address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse vault address %s: %v", address, err)
+		}
+		if err := httpClient.certificate.VerifyHostname(addressURL.Hostname()); err != nil {
+			return nil, fmt.Errorf("the certificate of the TLS secret %s is not valid for %s, add it to the SANs of the certificate",
+				v.Spec.ExistingTLSSecretName, addressURL.Hostname())
+		}
+	}
+
+	config := api.DefaultConfig()
+	if config.Error != nil {
+		return nil, config.Error
+	}
+	config.HttpClient = httpClient.client
+	config.Address = address
+
+	return api.NewClient(config)
+}
+
+// httpClientForVault returns the cached HTTP client of a Vault, and replaces it if the CA has changed since
+func (cache *vaultHTTPClientCache) httpClientForVault(ctx context.Context, c client.Client, v *vaultv1alpha1.Vault) (vaultHTTPClient, error) {
+	caCertificate, certificate, err := tlsCertificatesForVault(ctx, c, v)
+	if err != nil {
+		return vaultHTTPClient{}, err
+	}
+	tlsHash := fmt.Sprintf("%t/%x/%x", v.Spec.InsecureSkipTLSVerify, sha256.Sum256(caCertificate), sha256.Sum256(certificate))
+
+	cache.Lock()
+	defer cache.Unlock()
+
+	key := types.NamespacedName{Namespace: v.Namespace, Name: v.Name}
+	cached, ok := cache.clients[key]
+	if ok && cached.tlsHash == tlsHash {
+		return cached, nil
+	}
+
+	httpClient, err := newVaultHTTPClient(caCertificate, v.Spec.InsecureSkipTLSVerify)
+	if err != nil {
+		return vaultHTTPClient{}, err
+	}
+	if ok {
+		cached.client.CloseIdleConnections()
+	}
+
+	entry := vaultHTTPClient{tlsHash: tlsHash, client: httpClient}
+	if len(certificate) != 0 {
+		block, _ := pem.Decode(certificate)
+		if block == nil {
+			return vaultHTTPClient{}, fmt.Errorf("failed to parse the certificate of the TLS secret %s", v.Spec.ExistingTLSSecretName)
+		}
+		if entry.certificate, err = x509.ParseCertificate(block.Bytes); err != nil {
+			return vaultHTTPClient{}, fmt.Errorf("failed to parse the certificate of the TLS secret %s: %v", v.Spec.ExistingTLSSecretName, err)
+		}
+	}
+	cache.clients[key] = entry
+
+	return entry, nil
+}
+
+// forgetVault closes the connections of a deleted Vault
+func (cache *vaultHTTPClientCache) forgetVault(key types.NamespacedName) {
+	cache.Lock()
+	defer cache.Unlock()
+
+	if cached, ok := cache.clients[key]; ok {
+		cached.client.CloseIdleConnections()
+		delete(cache.clients, key)
+	}
+}
+
+// cachedOperatorToken returns the cached token of the operator identity of a Vault, if it was issued for the identity
+func (cache *vaultHTTPClientCache) cachedOperatorToken(key types.NamespacedName, identityHash string) *operatorToken {
+	cache.Lock()
+	defer cache.Unlock()
+
+	token := cache.clients[key].operatorToken
+	if token == nil || token.identityHash != identityHash {
+		return nil
+	}
+	return token
+}
+
+// cacheOperatorToken caches the token of the operator identity next to the HTTP client of a Vault
+func (cache *vaultHTTPClientCache) cacheOperatorToken(key types.NamespacedName, token *operatorToken) {
+	cache.Lock()
+	defer cache.Unlock()
+
+	if cached, ok := cache.clients[key]; ok {
+		cached.operatorToken = token
+		cache.clients[key] = cached
+	}
+}
+
+// tlsCertificatesForVault returns the CA certificate of the generated or the existing TLS Secret of a Vault,
+// and the certificate of an existing one, or nothing if TLS is disabled or not verified
+func tlsCertificatesForVault(ctx context.Context, c client.Client, v *vaultv1alpha1.Vault) ([]byte, []byte, error) {
+	if v.Spec.IsTLSDisabled() || v.Spec.InsecureSkipTLSVerify {
+		return nil, nil, nil
+	}
+
+	secretName := v.Name + "-tls"
+	if v.Spec.ExistingTLSSecretName != "" {
+		secretName = v.Spec.ExistingTLSSecretName
+	}
+
+	secret := corev1.Secret{}
+	err := c.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: secretName}, &secret)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get the TLS secret %s to verify vault with: %v", secretName, err)
+	}
+
+	// The generated certificates cover the pod addresses
+	var certificate []byte
+	if v.Spec.ExistingTLSSecretName != "" {
+		certificate = secret.Data[corev1.TLSCertKey]
+	}
+
+	// Without a CA in an existing Secret the certificates are verified with the system roots
+	return secret.Data["ca.crt"], certificate, nil
+}
+
+// newVaultHTTPClient returns an HTTP client trusting the CA certificate, or skipping verification if insecure is set
+func newVaultHTTPClient(caCertificate []byte, insecure bool) (*http.Client, error) {
+	config := api.DefaultConfig()
+	if config.Error != nil {
+		return nil, config.Error
+	}
+
+	transport := config.HttpClient.Transport.(*http.Transport)
+	transport.TLSHandshakeTimeout = 5 * time.Second
+	transport.TLSClientConfig.ClientSessionCache = tls.NewLRUClientSessionCache(0)
+
+	if insecure {
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	} else if len(caCertificate) != 0 {
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caCertificate) {
+			return nil, fmt.Errorf("failed to parse the CA certificate of vault")
+		}
+		transport.TLSClientConfig.RootCAs = rootCAs
+	}
+
+	return config.HttpClient, nil
+}
@@ -0,0 +1,101 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestVaultHTTPClient(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"initialized": true, "sealed": false, "standby": false}`))
+	}))
+	defer server.Close()
+	caCertificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	v := &vaultv1alpha1.Vault{ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"}}
+	tlsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-tls", Namespace: "vault"},
+		Data:       map[string][]byte{"ca.crt": caCertificate},
+	}
+	r, c := newTestReconciler(t, tlsSecret)
+
+	// The certificate of the server is verified with the CA of the TLS Secret
+	vaultClient, err := r.httpClients.newVaultClient(context.Background(), c, v, server.URL)
+	require.NoError(t, err)
+	vaultClient.SetMaxRetries(0)
+	_, err = vaultClient.Sys().Health()
+	require.NoError(t, err)
+
+	// The HTTP client is reused until the CA changes
+	cached, err := r.httpClients.httpClientForVault(context.Background(), c, v)
+	require.NoError(t, err)
+	assert.Same(t, vaultClient.CloneConfig().HttpClient.Transport, cached.client.Transport)
+
+	tlsSecret.Data["ca.crt"] = nil
+	require.NoError(t, c.Update(context.Background(), tlsSecret))
+	vaultClient, err = r.httpClients.newVaultClient(context.Background(), c, v, server.URL)
+	require.NoError(t, err)
+	vaultClient.SetMaxRetries(0)
+	_, err = vaultClient.Sys().Health()
+	assert.ErrorContains(t, err, "certificate")
+
+	// Only an explicit opt-in skips the verification
+	v.Spec.InsecureSkipTLSVerify = true
+	vaultClient, err = r.httpClients.newVaultClient(context.Background(), c, v, server.URL)
+	require.NoError(t, err)
+	vaultClient.SetMaxRetries(0)
+	_, err = vaultClient.Sys().Health()
+	require.NoError(t, err)
+
+	// The certificate of an existing TLS Secret has to be valid for the pod addresses
+	v.Spec.InsecureSkipTLSVerify = false
+	existing := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-existing-tls", Namespace: "vault"},
+		Data: map[string][]byte{
+			"ca.crt":  caCertificate,
+			"tls.crt": caCertificate,
+		},
+	}
+	require.NoError(t, c.Create(context.Background(), existing))
+	v.Spec.ExistingTLSSecretName = existing.Name
+	_, err = r.httpClients.newVaultClient(context.Background(), c, v, server.URL)
+	require.NoError(t, err)
+	_, err = r.httpClients.vaultClientForPod(context.Background(), c, v, "vault-0")
+	assert.EqualError(t, err, "the certificate of the TLS secret vault-existing-tls is not valid for vault-0.vault-headless.vault.svc.cluster.local, "+
+		"add it to the SANs of the certificate")
+
+	// A missing TLS Secret fails instead of falling back to insecure mode
+	v.Spec.ExistingTLSSecretName = ""
+	require.NoError(t, c.Delete(context.Background(), tlsSecret))
+	_, err = r.httpClients.newVaultClient(context.Background(), c, v, server.URL)
+	assert.Error(t, err)
+
+	// The connections of a deleted Vault are closed
+	r.httpClients.forgetVault(client.ObjectKeyFromObject(v))
+	assert.Empty(t, r.httpClients.clients)
+}
@@ -0,0 +1,559 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	"github.com/spf13/cast"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// vaultConfigFinalizer makes sure the configuration is removed from Vault before the resource is gone
+const vaultConfigFinalizer = "vault.banzaicloud.com/config-cleanup"
+
+// vaultConfigRetryPeriod is used when Vault can't be configured yet, e.g. it has no leader
+const vaultConfigRetryPeriod = 30 * time.Second
+
+// vaultConfigObject is implemented by the resources configuring a part of a Vault cluster
+type vaultConfigObject interface {
+	client.Object
+	GetVaultReference() types.NamespacedName
+	GetConfigStatus() *vaultv1alpha1.VaultConfigStatus
+}
+
+// ReconcileVaultConfig reconciles one kind of Vault configuration resource
+type ReconcileVaultConfig[T vaultConfigObject] struct {
+	client              client.Client
+	nonNamespacedClient client.Client
+	// httpClients is shared by the controllers of the configuration resources
+	httpClients *vaultHTTPClientCache
+
+	newObject func() T
+	newList   func() client.ObjectList
+	// vaultName returns the name of the policy, or the path of the mount, the resource configures in Vault
+	vaultName func(*vaultv1alpha1.Vault, T) string
+	// exists returns true if the policy or the mount is already present in Vault
+	exists func(*api.Client, string) (bool, error)
+	apply  func(*api.Client, T, string) error
+	remove func(*api.Client, T, string) error
+}
+
+// AddConfigResources creates the controllers of the VaultPolicy, VaultAuthBackend, VaultSecretEngine
+// and VaultAuditDevice resources and adds them to the Manager
+func AddConfigResources(mgr manager.Manager) error {
+	nonNamespacedClient, err := client.New(mgr.GetConfig(), client.Options{})
+	if err != nil {
+		return err
+	}
+	httpClients := newVaultHTTPClientCache()
+
+	err = addConfigResource(mgr, "vaultpolicy-controller", &ReconcileVaultConfig[*vaultv1alpha1.VaultPolicy]{
+		client:              mgr.GetClient(),
+		nonNamespacedClient: nonNamespacedClient,
+		httpClients:         httpClients,
+		newObject:           func() *vaultv1alpha1.VaultPolicy { return &vaultv1alpha1.VaultPolicy{} },
+		newList:             func() client.ObjectList { return &vaultv1alpha1.VaultPolicyList{} },
+		vaultName:           vaultPolicyName,
+		exists:              vaultPolicyExists,
+		apply:               applyVaultPolicy,
+		remove:              removeVaultPolicy,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = addConfigResource(mgr, "vaultauthbackend-controller", &ReconcileVaultConfig[*vaultv1alpha1.VaultAuthBackend]{
+		client:              mgr.GetClient(),
+		nonNamespacedClient: nonNamespacedClient,
+		httpClients:         httpClients,
+		newObject:           func() *vaultv1alpha1.VaultAuthBackend { return &vaultv1alpha1.VaultAuthBackend{} },
+		newList:             func() client.ObjectList { return &vaultv1alpha1.VaultAuthBackendList{} },
+		vaultName:           vaultAuthBackendName,
+		exists:              vaultAuthExists,
+		apply:               applyVaultAuthBackend,
+		remove:              removeVaultAuthBackend,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = addConfigResource(mgr, "vaultsecretengine-controller", &ReconcileVaultConfig[*vaultv1alpha1.VaultSecretEngine]{
+		client:              mgr.GetClient(),
+		nonNamespacedClient: nonNamespacedClient,
+		httpClients:         httpClients,
+		newObject:           func() *vaultv1alpha1.VaultSecretEngine { return &vaultv1alpha1.VaultSecretEngine{} },
+		newList:             func() client.ObjectList { return &vaultv1alpha1.VaultSecretEngineList{} },
+		vaultName:           vaultSecretEngineName,
+		exists:              vaultMountExists,
+		apply:               applyVaultSecretEngine,
+		remove:              removeVaultSecretEngine,
+	})
+	if err != nil {
+		return err
+	}
+
+	return addConfigResource(mgr, "vaultauditdevice-controller", &ReconcileVaultConfig[*vaultv1alpha1.VaultAuditDevice]{
+		client:              mgr.GetClient(),
+		nonNamespacedClient: nonNamespacedClient,
+		httpClients:         httpClients,
+		newObject:           func() *vaultv1alpha1.VaultAuditDevice { return &vaultv1alpha1.VaultAuditDevice{} },
+		newList:             func() client.ObjectList { return &vaultv1alpha1.VaultAuditDeviceList{} },
+		vaultName:           vaultAuditDeviceName,
+		exists:              vaultAuditExists,
+		apply:               applyVaultAuditDevice,
+		remove:              removeVaultAuditDevice,
+	})
+}
+
+func addConfigResource[T vaultConfigObject](mgr manager.Manager, name string, r *ReconcileVaultConfig[T]) error {
+	c, err := controller.New(name, mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	err = c.Watch(source.Kind(mgr.GetCache(), r.newObject(), &handler.TypedEnqueueRequestForObject[T]{}))
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the referenced Vaults, so the configuration is applied as soon as a leader is elected
+	return c.Watch(source.Kind(mgr.GetCache(), &vaultv1alpha1.Vault{}, handler.TypedEnqueueRequestsFromMapFunc(r.requestsForVault)))
+}
+
+// requestsForVault enqueues the configuration resources referencing the Vault
+func (r *ReconcileVaultConfig[T]) requestsForVault(ctx context.Context, v *vaultv1alpha1.Vault) []reconcile.Request {
+	list := r.newList()
+	if err := r.client.List(ctx, list); err != nil {
+		log.Error(err, "failed to list vault configuration resources")
+		return nil
+	}
+
+	objects, err := meta.ExtractList(list)
+	if err != nil {
+		log.Error(err, "failed to extract vault configuration resources")
+		return nil
+	}
+
+	vaultKey := client.ObjectKeyFromObject(v)
+	var requests []reconcile.Request
+	for _, o := range objects {
+		if object, ok := o.(T); ok && object.GetVaultReference() == vaultKey {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(object)})
+		}
+	}
+
+	return requests
+}
+
+// Reconcile applies the configuration resource to the referenced Vault, and removes it from Vault on deletion
+func (r *ReconcileVaultConfig[T]) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	object := r.newObject()
+	err := r.client.Get(ctx, request.NamespacedName, object)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	v := &vaultv1alpha1.Vault{}
+	err = r.client.Get(ctx, object.GetVaultReference(), v)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	vaultFound := err == nil
+	if !vaultFound {
+		r.httpClients.forgetVault(object.GetVaultReference())
+	}
+
+	if !object.GetDeletionTimestamp().IsZero() {
+		if !controllerutil.ContainsFinalizer(object, vaultConfigFinalizer) {
+			return reconcile.Result{}, nil
+		}
+
+		// There is nothing to clean up if the Vault is gone or this resource created nothing in it
+		created := object.GetConfigStatus().Created
+		if vaultFound && v.AllowsConfigResourcesFrom(object.GetNamespace()) && created != "" {
+			if v.Status.Leader == "" {
+				log.Info("waiting for the vault leader to remove configuration", "vault", v.Name, "resource", request.NamespacedName)
+				return reconcile.Result{RequeueAfter: vaultConfigRetryPeriod}, nil
+			}
+
+			vaultClient, err := r.httpClients.adminClientForVault(ctx, r.nonNamespacedClient, v, podAddressForVault(v, v.Status.Leader))
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+
+			if err := r.remove(vaultClient, object, created); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+
+		controllerutil.RemoveFinalizer(object, vaultConfigFinalizer)
+		return reconcile.Result{}, r.client.Update(ctx, object)
+	}
+
+	if controllerutil.AddFinalizer(object, vaultConfigFinalizer) {
+		if err := r.client.Update(ctx, object); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	status := object.GetConfigStatus()
+	applied := status.GetCondition(vaultv1alpha1.ConfigurationAppliedCondition)
+	if applied != nil && applied.Status == corev1.ConditionTrue && status.ObservedGeneration == object.GetGeneration() {
+		return reconcile.Result{}, nil
+	}
+
+	var result reconcile.Result
+	condition := corev1.ComponentCondition{
+		Type:   vaultv1alpha1.ConfigurationAppliedCondition,
+		Status: corev1.ConditionFalse,
+	}
+
+	switch {
+	case !vaultFound:
+		condition.Error = fmt.Sprintf("vault %s not found", object.GetVaultReference())
+		result.RequeueAfter = vaultConfigRetryPeriod
+	case !v.AllowsConfigResourcesFrom(object.GetNamespace()):
+		condition.Error = fmt.Sprintf("vault %s doesn't allow configuration resources from namespace %s", object.GetVaultReference(), object.GetNamespace())
+	case v.Status.Leader == "":
+		condition.Error = fmt.Sprintf("vault %s has no leader", object.GetVaultReference())
+		result.RequeueAfter = vaultConfigRetryPeriod
+	default:
+		err = func() error {
+			vaultClient, err := r.httpClients.adminClientForVault(ctx, r.nonNamespacedClient, v, podAddressForVault(v, v.Status.Leader))
+			if err != nil {
+				return err
+			}
+
+			return r.applyToVault(vaultClient, v, object)
+		}()
+		if err != nil {
+			condition.Error = err.Error()
+			result.RequeueAfter = vaultConfigRetryPeriod
+		} else {
+			condition.Status = corev1.ConditionTrue
+			status.ObservedGeneration = object.GetGeneration()
+		}
+	}
+
+	if status.SetCondition(condition) || condition.Status == corev1.ConditionTrue {
+		if err := r.client.Status().Update(ctx, object); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to update status: %v", err)
+		}
+	}
+
+	return result, nil
+}
+
+// applyToVault applies the resource under its name in Vault, it refuses to take over a policy or a mount
+// it hasn't created, and records the name it has created in the status of the resource
+func (r *ReconcileVaultConfig[T]) applyToVault(vaultClient *api.Client, v *vaultv1alpha1.Vault, object T) error {
+	status := object.GetConfigStatus()
+	name := r.vaultName(v, object)
+	if reservedPolicy(object, name) {
+		return fmt.Errorf("policy %s is reserved for vault and the operator", name)
+	}
+	if err := checkRolePolicies(v, object); err != nil {
+		return err
+	}
+
+	// Only what this resource created may be changed, the rest belongs to the Vault or other resources
+	if name != status.Created {
+		exists, err := r.exists(vaultClient, name)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("%s already exists in vault and is not managed by this resource", name)
+		}
+	}
+
+	if err := r.apply(vaultClient, object, name); err != nil {
+		// A half applied new name would be refused as foreign on the next attempt
+		if name != status.Created {
+			if rollbackErr := r.remove(vaultClient, object, name); rollbackErr != nil {
+				return fmt.Errorf("%v, and failed to roll it back: %v", err, rollbackErr)
+			}
+		}
+		return err
+	}
+
+	// A renamed policy or moved mount leaves the previous one behind otherwise
+	if status.Created != "" && status.Created != name {
+		if err := r.remove(vaultClient, object, status.Created); err != nil {
+			return err
+		}
+	}
+	status.Created = name
+
+	return nil
+}
+
+func unmarshalExtJSON(raw extv1beta1.JSON, out interface{}) error {
+	if len(raw.Raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(raw.Raw, out)
+}
+
+// configResourceName returns the name of a configuration resource in Vault, the resources of other namespaces
+// than the one of the Vault are kept under their namespace, so they can't take over the names of other tenants
+// or of the Vault itself
+func configResourceName(v *vaultv1alpha1.Vault, object client.Object, name, separator string) string {
+	if object.GetNamespace() == v.Namespace {
+		return name
+	}
+	return object.GetNamespace() + separator + name
+}
+
+// reservedPolicy returns true for the policies of Vault itself and the ones written by the operator
+func reservedPolicy(object client.Object, name string) bool {
+	if _, ok := object.(*vaultv1alpha1.VaultPolicy); !ok {
+		return false
+	}
+	return name == "root" || name == "default" || name == operatorIdentityName || strings.HasPrefix(name, "transit-unseal-")
+}
+
+// checkRolePolicies refuses the roles of auth methods of other namespaces than the one of the Vault granting policies
+// outside of their namespace, like the one of the operator or the ones of other tenants
+func checkRolePolicies(v *vaultv1alpha1.Vault, object client.Object) error {
+	auth, ok := object.(*vaultv1alpha1.VaultAuthBackend)
+	if !ok || auth.Namespace == v.Namespace {
+		return nil
+	}
+
+	for _, raw := range auth.Spec.Roles {
+		var role map[string]interface{}
+		if err := unmarshalExtJSON(raw, &role); err != nil {
+			return fmt.Errorf("failed to parse role: %v", err)
+		}
+		for _, field := range []string{"policies", "token_policies"} {
+			for _, policy := range sortedValues(role[field]) {
+				// Every token has the default policy unless a role opts out of it
+				if policy != "default" && !strings.HasPrefix(policy, auth.Namespace+".") {
+					return fmt.Errorf("role %s can't grant policy %s, the policies of namespace %s are named %s.<name>",
+						cast.ToString(role["name"]), policy, auth.Namespace, auth.Namespace)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// vaultPolicyName keeps the policies of other namespaces as <namespace>.<name>, a namespace has no dots
+func vaultPolicyName(v *vaultv1alpha1.Vault, policy *vaultv1alpha1.VaultPolicy) string {
+	return configResourceName(v, policy, policy.PolicyName(), ".")
+}
+
+func vaultPolicyExists(vaultClient *api.Client, name string) (bool, error) {
+	rules, err := vaultClient.Sys().GetPolicy(name)
+	if err != nil {
+		return false, fmt.Errorf("failed to read policy %s: %v", name, err)
+	}
+	return rules != "", nil
+}
+
+func applyVaultPolicy(vaultClient *api.Client, policy *vaultv1alpha1.VaultPolicy, name string) error {
+	if err := vaultClient.Sys().PutPolicy(name, policy.Spec.Rules); err != nil {
+		return fmt.Errorf("failed to put policy %s: %v", name, err)
+	}
+	return nil
+}
+
+func removeVaultPolicy(vaultClient *api.Client, _ *vaultv1alpha1.VaultPolicy, name string) error {
+	if err := vaultClient.Sys().DeletePolicy(name); err != nil {
+		return fmt.Errorf("failed to delete policy %s: %v", name, err)
+	}
+	return nil
+}
+
+// vaultAuthBackendName keeps the auth methods of other namespaces under <namespace>/
+func vaultAuthBackendName(v *vaultv1alpha1.Vault, auth *vaultv1alpha1.VaultAuthBackend) string {
+	return configResourceName(v, auth, mountPath(auth.Spec.Path, auth.Spec.Type), "/")
+}
+
+func vaultAuthExists(vaultClient *api.Client, path string) (bool, error) {
+	existing, err := vaultClient.Sys().ListAuth()
+	if err != nil {
+		return false, fmt.Errorf("failed to list auth methods: %v", err)
+	}
+	_, ok := existing[path+"/"]
+	return ok, nil
+}
+
+func applyVaultAuthBackend(vaultClient *api.Client, auth *vaultv1alpha1.VaultAuthBackend, path string) error {
+	a := externalAuth{
+		Type:        auth.Spec.Type,
+		Path:        path,
+		Description: auth.Spec.Description,
+	}
+	if err := unmarshalExtJSON(auth.Spec.Options, &a.Options); err != nil {
+		return fmt.Errorf("failed to parse options: %v", err)
+	}
+	if err := unmarshalExtJSON(auth.Spec.Config, &a.Config); err != nil {
+		return fmt.Errorf("failed to parse config: %v", err)
+	}
+	for _, raw := range auth.Spec.Roles {
+		var role map[string]interface{}
+		if err := unmarshalExtJSON(raw, &role); err != nil {
+			return fmt.Errorf("failed to parse role: %v", err)
+		}
+		a.Roles = append(a.Roles, role)
+	}
+
+	// The type of an auth method can't be changed, it has to be enabled at another path
+	existing, err := vaultClient.Sys().ListAuth()
+	if err != nil {
+		return fmt.Errorf("failed to list auth methods: %v", err)
+	}
+	if mount, ok := existing[path+"/"]; ok && mount.Type != a.Type {
+		return fmt.Errorf("auth method %s has type %s, a %s auth method needs another path", path, mount.Type, a.Type)
+	}
+
+	return applyAuth(vaultClient, []externalAuth{a})
+}
+
+func removeVaultAuthBackend(vaultClient *api.Client, _ *vaultv1alpha1.VaultAuthBackend, path string) error {
+	if exists, err := vaultAuthExists(vaultClient, path); err != nil || !exists {
+		return err
+	}
+	if err := vaultClient.Sys().DisableAuth(path); err != nil {
+		return fmt.Errorf("failed to disable auth method %s: %v", path, err)
+	}
+	return nil
+}
+
+// vaultSecretEngineName keeps the secret engines of other namespaces under <namespace>/
+func vaultSecretEngineName(v *vaultv1alpha1.Vault, engine *vaultv1alpha1.VaultSecretEngine) string {
+	return configResourceName(v, engine, mountPath(engine.Spec.Path, engine.Spec.Type), "/")
+}
+
+func vaultMountExists(vaultClient *api.Client, path string) (bool, error) {
+	existing, err := vaultClient.Sys().ListMounts()
+	if err != nil {
+		return false, fmt.Errorf("failed to list secret engines: %v", err)
+	}
+	_, ok := existing[path+"/"]
+	return ok, nil
+}
+
+func applyVaultSecretEngine(vaultClient *api.Client, engine *vaultv1alpha1.VaultSecretEngine, path string) error {
+	e := externalSecretEngine{
+		Type:        engine.Spec.Type,
+		Path:        path,
+		Description: engine.Spec.Description,
+		Local:       engine.Spec.Local,
+		SealWrap:    engine.Spec.SealWrap,
+		Options:     map[string]interface{}{},
+	}
+	for k, v := range engine.Spec.Options {
+		e.Options[k] = v
+	}
+	if err := unmarshalExtJSON(engine.Spec.Config, &e.Config); err != nil {
+		return fmt.Errorf("failed to parse config: %v", err)
+	}
+	if err := unmarshalExtJSON(engine.Spec.Configuration, &e.Configuration); err != nil {
+		return fmt.Errorf("failed to parse configuration: %v", err)
+	}
+
+	// Only the options and the config of a mounted secret engine can be tuned, the rest needs another path
+	existing, err := vaultClient.Sys().ListMounts()
+	if err != nil {
+		return fmt.Errorf("failed to list secret engines: %v", err)
+	}
+	if mount, ok := existing[path+"/"]; ok {
+		switch {
+		case mount.Type != e.Type:
+			return fmt.Errorf("secret engine %s has type %s, a %s secret engine needs another path", path, mount.Type, e.Type)
+		case mount.Local != e.Local || mount.SealWrap != e.SealWrap:
+			return fmt.Errorf("secret engine %s can't change local or sealWrap once mounted, it needs another path", path)
+		}
+	}
+
+	return applySecretEngines(vaultClient, []externalSecretEngine{e})
+}
+
+func removeVaultSecretEngine(vaultClient *api.Client, engine *vaultv1alpha1.VaultSecretEngine, path string) error {
+	if engine.Spec.RetainOnDelete {
+		return nil
+	}
+	if exists, err := vaultMountExists(vaultClient, path); err != nil || !exists {
+		return err
+	}
+	if err := vaultClient.Sys().Unmount(path); err != nil {
+		return fmt.Errorf("failed to unmount secret engine %s: %v", path, err)
+	}
+	return nil
+}
+
+// vaultAuditDeviceName keeps the audit devices of other namespaces under <namespace>/
+func vaultAuditDeviceName(v *vaultv1alpha1.Vault, audit *vaultv1alpha1.VaultAuditDevice) string {
+	return configResourceName(v, audit, mountPath(audit.Spec.Path, audit.Spec.Type), "/")
+}
+
+func vaultAuditExists(vaultClient *api.Client, path string) (bool, error) {
+	existing, err := vaultClient.Sys().ListAudit()
+	if err != nil {
+		return false, fmt.Errorf("failed to list audit devices: %v", err)
+	}
+	_, ok := existing[path+"/"]
+	return ok, nil
+}
+
+func applyVaultAuditDevice(vaultClient *api.Client, audit *vaultv1alpha1.VaultAuditDevice, path string) error {
+	a := externalAudit{
+		Type:        audit.Spec.Type,
+		Path:        path,
+		Description: audit.Spec.Description,
+		Local:       audit.Spec.Local,
+		Options:     map[string]interface{}{},
+	}
+	for k, v := range audit.Spec.Options {
+		a.Options[k] = v
+	}
+
+	return applyAudit(vaultClient, []externalAudit{a})
+}
+
+func removeVaultAuditDevice(vaultClient *api.Client, _ *vaultv1alpha1.VaultAuditDevice, path string) error {
+	if exists, err := vaultAuditExists(vaultClient, path); err != nil || !exists {
+		return err
+	}
+	if err := vaultClient.Sys().DisableAudit(path); err != nil {
+		return fmt.Errorf("failed to disable audit device %s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+// Copyright © 2025 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestReconcileVaultConfigConditions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, vaultv1alpha1.AddToScheme(scheme))
+
+	tests := []struct {
+		name      string
+		vault     *vaultv1alpha1.Vault
+		namespace string
+		wantError string
+		requeue   bool
+	}{
+		{
+			name:      "vault not found",
+			namespace: "default",
+			wantError: "vault default/vault not found",
+			requeue:   true,
+		},
+		{
+			name: "namespace not allowed",
+			vault: &vaultv1alpha1.Vault{
+				ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+				Status:     vaultv1alpha1.VaultStatus{Leader: "vault-0"},
+			},
+			namespace: "team-a",
+			wantError: "vault default/vault doesn't allow configuration resources from namespace team-a",
+		},
+		{
+			name: "no leader",
+			vault: &vaultv1alpha1.Vault{
+				ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+				Spec:       vaultv1alpha1.VaultSpec{ConfigResourceNamespaces: []string{"team-a"}},
+			},
+			namespace: "team-a",
+			wantError: "vault default/vault has no leader",
+			requeue:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &vaultv1alpha1.VaultPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: tt.namespace},
+				Spec: vaultv1alpha1.VaultPolicySpec{
+					VaultRef: vaultv1alpha1.VaultReference{Name: "vault", Namespace: "default"},
+					Rules:    `path "secret/*" { capabilities = ["read"] }`,
+				},
+			}
+
+			builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(policy).WithStatusSubresource(policy)
+			if tt.vault != nil {
+				builder = builder.WithObjects(tt.vault)
+			}
+			c := builder.Build()
+
+			r := &ReconcileVaultConfig[*vaultv1alpha1.VaultPolicy]{
+				client:              c,
+				nonNamespacedClient: c,
+				httpClients:         newVaultHTTPClientCache(),
+				newObject:           func() *vaultv1alpha1.VaultPolicy { return &vaultv1alpha1.VaultPolicy{} },
+				newList:             func() client.ObjectList { return &vaultv1alpha1.VaultPolicyList{} },
+				vaultName:           vaultPolicyName,
+				exists:              vaultPolicyExists,
+				apply:               applyVaultPolicy,
+				remove:              removeVaultPolicy,
+			}
+
+			result, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(policy)})
+			require.NoError(t, err)
+			assert.Equal(t, tt.requeue, result.RequeueAfter > 0)
+
+			got := &vaultv1alpha1.VaultPolicy{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(policy), got))
+			assert.Contains(t, got.Finalizers, vaultConfigFinalizer)
+
+			condition := got.Status.GetCondition(vaultv1alpha1.ConfigurationAppliedCondition)
+			require.NotNil(t, condition)
+			assert.Equal(t, corev1.ConditionFalse, condition.Status)
+			assert.Equal(t, tt.wantError, condition.Error)
+			assert.Zero(t, got.Status.ObservedGeneration)
+		})
+	}
+}
+
+func TestApplyToVault(t *testing.T) {
+	var mu sync.Mutex
+	policies := map[string]string{"shared": `path "secret/*" { capabilities = ["read"] }`}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		name := strings.TrimPrefix(r.URL.Path, "/v1/sys/policies/acl/")
+		switch r.Method {
+		case http.MethodGet:
+			rules, ok := policies[name]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]string{"name": name, "policy": rules}})
+		case http.MethodPut:
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			policies[name] = body["policy"]
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			delete(policies, name)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	vaultClient, err := api.NewClient(&api.Config{Address: server.URL})
+	require.NoError(t, err)
+
+	r := &ReconcileVaultConfig[*vaultv1alpha1.VaultPolicy]{
+		vaultName: vaultPolicyName,
+		exists:    vaultPolicyExists,
+		apply:     applyVaultPolicy,
+		remove:    removeVaultPolicy,
+	}
+	v := &vaultv1alpha1.Vault{ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"}}
+	policy := &vaultv1alpha1.VaultPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader", Namespace: "team-a"},
+		Spec:       vaultv1alpha1.VaultPolicySpec{Rules: `path "team-a/*" { capabilities = ["read"] }`},
+	}
+
+	// Policies of other namespaces are kept under their namespace
+	require.NoError(t, r.applyToVault(vaultClient, v, policy))
+	assert.Equal(t, "team-a.reader", policy.Status.Created)
+	assert.Contains(t, policies, "team-a.reader")
+
+	// Renaming onto a policy of someone else is refused and leaves it alone
+	policy.Spec.Name = "shared"
+	policy.Spec.VaultRef.Namespace = "default"
+	policies["team-a.shared"] = "foreign"
+	err = r.applyToVault(vaultClient, v, policy)
+	require.EqualError(t, err, "team-a.shared already exists in vault and is not managed by this resource")
+	assert.Equal(t, "team-a.reader", policy.Status.Created)
+	assert.Equal(t, "foreign", policies["team-a.shared"])
+
+	// A rename to a free name removes the previous policy
+	policy.Spec.Name = "writer"
+	require.NoError(t, r.applyToVault(vaultClient, v, policy))
+	assert.Equal(t, "team-a.writer", policy.Status.Created)
+	assert.NotContains(t, policies, "team-a.reader")
+
+	// The policies of Vault and of the operator can't be configured from the Vault namespace either
+	policy.Namespace = "default"
+	policy.Spec.Name = operatorIdentityName
+	require.EqualError(t, r.applyToVault(vaultClient, v, policy), "policy vault-operator is reserved for vault and the operator")
+}
+
+func TestCheckRolePolicies(t *testing.T) {
+	v := &vaultv1alpha1.Vault{ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"}}
+	auth := &vaultv1alpha1.VaultAuthBackend{
+		ObjectMeta: metav1.ObjectMeta{Name: "kubernetes", Namespace: "team-a"},
+		Spec: vaultv1alpha1.VaultAuthBackendSpec{
+			Type: "kubernetes",
+			Roles: []extv1beta1.JSON{
+				{Raw: []byte(`{"name": "app", "policies": "team-a.reader,default"}`)},
+				{Raw: []byte(`{"name": "worker", "token_policies": ["team-a.writer"]}`)},
+			},
+		},
+	}
+	require.NoError(t, checkRolePolicies(v, auth))
+
+	// The roles of other namespaces can't grant the policy of the operator or the ones of other tenants
+	auth.Spec.Roles = append(auth.Spec.Roles, extv1beta1.JSON{Raw: []byte(`{"name": "admin", "token_policies": ["team-b.reader", "vault-operator"]}`)})
+	require.EqualError(t, checkRolePolicies(v, auth),
+		"role admin can't grant policy team-b.reader, the policies of namespace team-a are named team-a.<name>")
+
+	// The auth methods of the Vault namespace can grant any policy
+	auth.Namespace = "default"
+	require.NoError(t, checkRolePolicies(v, auth))
+}
+
+func TestApplyVaultSecretEngineRefusesImmutableChanges(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"team-a/secrets/": {"type": "kv", "local": false}}}`))
+	}))
+	defer server.Close()
+
+	vaultClient, err := api.NewClient(&api.Config{Address: server.URL})
+	require.NoError(t, err)
+
+	engine := &vaultv1alpha1.VaultSecretEngine{Spec: vaultv1alpha1.VaultSecretEngineSpec{Type: "kv", Local: true}}
+	require.EqualError(t, applyVaultSecretEngine(vaultClient, engine, "team-a/secrets"),
+		"secret engine team-a/secrets can't change local or sealWrap once mounted, it needs another path")
+
+	engine.Spec.Type = "database"
+	require.EqualError(t, applyVaultSecretEngine(vaultClient, engine, "team-a/secrets"),
+		"secret engine team-a/secrets has type kv, a database secret engine needs another path")
+}